@@ -0,0 +1,150 @@
+// Package audit scores a crawled page against a registry of pluggable SEO
+// rules and rolls per-page scores up to a site-level score, Lighthouse
+// style. Historical scores are meant to be persisted via
+// store/dynamo.Repository[dynamo.PageAudit] (per page) and
+// store/dynamo.Repository[dynamo.SiteScore] (per site, per day) so a
+// scheduled Lambda can diff consecutive crawls with DiffReport.
+package audit
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/bwatson/seo-solver/aws-go/seo/structured"
+)
+
+// Severity is how badly a Finding's rule failed.
+type Severity string
+
+const (
+	SeverityPass Severity = "pass"
+	SeverityWarn Severity = "warn"
+	SeverityFail Severity = "fail"
+)
+
+// Finding is one observation a Rule made about a page.
+type Finding struct {
+	RuleID   string
+	Severity Severity
+	Message  string
+}
+
+// PageContext is everything a Rule needs to evaluate a single crawled page.
+type PageContext struct {
+	URL        string
+	Doc        *goquery.Document
+	Headers    http.Header
+	Structured structured.Result
+}
+
+// Rule is a single pluggable SEO check. Weight controls how much the rule's
+// pass/warn/fail outcome contributes to the page's overall Score.
+type Rule interface {
+	ID() string
+	Weight() int
+	Check(*PageContext) []Finding
+}
+
+// Audit is the result of running a Registry against one PageContext.
+type Audit struct {
+	URL        string
+	Timestamp  time.Time
+	Score      int // 0-100, weighted across all rules in the registry
+	Findings   []Finding
+	RuleScores map[string]int // per-rule credit (0, 50, or 100), keyed by Rule.ID(); used by DiffReport
+}
+
+// Registry holds the set of rules a crawl is scored against.
+type Registry struct {
+	rules []Rule
+}
+
+// NewRegistry returns a Registry seeded with rules.
+func NewRegistry(rules ...Rule) *Registry {
+	return &Registry{rules: append([]Rule(nil), rules...)}
+}
+
+// Register adds rule to the registry.
+func (r *Registry) Register(rule Rule) {
+	r.rules = append(r.rules, rule)
+}
+
+// Run evaluates every registered rule against ctx and returns the resulting
+// Audit. A rule with no Findings is treated as a full pass; the worst
+// Finding a rule reports determines that rule's credit toward the overall
+// score (fail = none, warn = half, pass/no findings = full).
+func (r *Registry) Run(ctx *PageContext) Audit {
+	var findings []Finding
+	ruleScores := make(map[string]int, len(r.rules))
+	var totalWeight, earnedWeight int
+
+	for _, rule := range r.rules {
+		ruleFindings := rule.Check(ctx)
+		findings = append(findings, ruleFindings...)
+
+		credit := creditFor(ruleFindings)
+		ruleScores[rule.ID()] = int(credit * 100)
+
+		weight := rule.Weight()
+		totalWeight += weight
+		earnedWeight += int(float64(weight) * credit)
+	}
+
+	score := 100
+	if totalWeight > 0 {
+		score = earnedWeight * 100 / totalWeight
+	}
+
+	return Audit{
+		URL:        ctx.URL,
+		Timestamp:  time.Now(),
+		Score:      score,
+		Findings:   findings,
+		RuleScores: ruleScores,
+	}
+}
+
+// creditFor reduces a rule's findings to a 0, 0.5, or 1 credit based on the
+// single worst severity reported.
+func creditFor(findings []Finding) float64 {
+	worst := SeverityPass
+	for _, f := range findings {
+		if severityRank(f.Severity) > severityRank(worst) {
+			worst = f.Severity
+		}
+	}
+	switch worst {
+	case SeverityFail:
+		return 0
+	case SeverityWarn:
+		return 0.5
+	default:
+		return 1
+	}
+}
+
+func severityRank(s Severity) int {
+	switch s {
+	case SeverityFail:
+		return 2
+	case SeverityWarn:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// RollupSite averages a set of page Audits into a single 0-100 site score.
+// Pages are weighted equally; an empty slice rolls up to 0.
+func RollupSite(audits []Audit) int {
+	if len(audits) == 0 {
+		return 0
+	}
+	var total int
+	for _, a := range audits {
+		total += a.Score
+	}
+	return total / len(audits)
+}
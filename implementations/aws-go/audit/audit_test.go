@@ -0,0 +1,89 @@
+package audit
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func mustParse(t *testing.T, html string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+type fixedRule struct {
+	id       string
+	weight   int
+	findings []Finding
+}
+
+func (r fixedRule) ID() string                   { return r.id }
+func (r fixedRule) Weight() int                  { return r.weight }
+func (r fixedRule) Check(*PageContext) []Finding { return r.findings }
+
+func TestRegistryRunScoring(t *testing.T) {
+	reg := NewRegistry(
+		fixedRule{id: "a", weight: 10, findings: nil},
+		fixedRule{id: "b", weight: 10, findings: []Finding{{Severity: SeverityFail}}},
+	)
+	audit := reg.Run(&PageContext{Doc: mustParse(t, "<html></html>")})
+
+	if audit.Score != 50 {
+		t.Errorf("Score = %d, want 50", audit.Score)
+	}
+	if audit.RuleScores["a"] != 100 || audit.RuleScores["b"] != 0 {
+		t.Errorf("RuleScores = %+v, want a=100 b=0", audit.RuleScores)
+	}
+}
+
+func TestRegistryRunWarnIsHalfCredit(t *testing.T) {
+	reg := NewRegistry(fixedRule{id: "a", weight: 10, findings: []Finding{{Severity: SeverityWarn}}})
+	audit := reg.Run(&PageContext{Doc: mustParse(t, "<html></html>")})
+	if audit.Score != 50 {
+		t.Errorf("Score = %d, want 50", audit.Score)
+	}
+}
+
+func TestDefaultRulesOnGoodPage(t *testing.T) {
+	html := `<html><head>
+		<title>A Perfectly Reasonable Page Title</title>
+		<meta name="description" content="This is a meta description that is long enough to satisfy the fifty to one hundred sixty character rule comfortably.">
+		<meta name="viewport" content="width=device-width, initial-scale=1">
+		<link rel="canonical" href="https://example.com/page">
+	</head><body>
+		<h1>Heading</h1>
+		<img src="a.png" alt="a">
+		<a href="/other">internal</a>
+	</body></html>`
+	doc := mustParse(t, html)
+
+	reg := NewRegistry(DefaultRules()...)
+	audit := reg.Run(&PageContext{
+		URL:     "https://example.com/page",
+		Doc:     doc,
+		Headers: http.Header{"Content-Encoding": []string{"gzip"}, "Cache-Control": []string{"max-age=3600"}},
+	})
+
+	if audit.Score < 90 {
+		t.Errorf("Score = %d for a well-formed page, want >= 90: findings=%+v", audit.Score, audit.Findings)
+	}
+}
+
+func TestDiffReportFlagsRegression(t *testing.T) {
+	prev := Audit{URL: "https://example.com/p", RuleScores: map[string]int{"title-length": 100, "canonical": 100}}
+	curr := Audit{URL: "https://example.com/p", RuleScores: map[string]int{"title-length": 0, "canonical": 100}}
+
+	report := DiffReport(prev, curr)
+	if len(report.Regressed) != 1 || report.Regressed[0].RuleID != "title-length" {
+		t.Errorf("Regressed = %+v, want one entry for title-length", report.Regressed)
+	}
+	if len(report.Improved) != 0 {
+		t.Errorf("Improved = %+v, want none", report.Improved)
+	}
+}
@@ -0,0 +1,47 @@
+package audit
+
+import "sort"
+
+// RuleDelta is how one rule's credit changed between two audits of the same
+// page.
+type RuleDelta struct {
+	RuleID    string
+	PrevScore int
+	CurrScore int
+}
+
+// Report is the outcome of diffing two Audits of the same page across
+// crawls.
+type Report struct {
+	URL        string
+	ScoreDelta int // curr.Score - prev.Score
+	Regressed  []RuleDelta
+	Improved   []RuleDelta
+}
+
+// DiffReport compares prev and curr, two Audits of the same page from
+// different crawls, and reports which rules regressed or improved so a
+// scheduled Lambda can alert when a deploy tanks a page's score. Rules
+// present in only one of the two audits (e.g. the registry changed between
+// crawls) are skipped rather than guessed at.
+func DiffReport(prev, curr Audit) Report {
+	report := Report{URL: curr.URL, ScoreDelta: curr.Score - prev.Score}
+
+	for ruleID, currScore := range curr.RuleScores {
+		prevScore, ok := prev.RuleScores[ruleID]
+		if !ok {
+			continue
+		}
+		switch {
+		case currScore < prevScore:
+			report.Regressed = append(report.Regressed, RuleDelta{ruleID, prevScore, currScore})
+		case currScore > prevScore:
+			report.Improved = append(report.Improved, RuleDelta{ruleID, prevScore, currScore})
+		}
+	}
+
+	sort.Slice(report.Regressed, func(i, j int) bool { return report.Regressed[i].RuleID < report.Regressed[j].RuleID })
+	sort.Slice(report.Improved, func(i, j int) bool { return report.Improved[i].RuleID < report.Improved[j].RuleID })
+
+	return report
+}
@@ -0,0 +1,242 @@
+package audit
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// DefaultRules returns the built-in rule set a crawl is ordinarily scored
+// against.
+func DefaultRules() []Rule {
+	return []Rule{
+		TitleLengthRule{},
+		MetaDescriptionRule{},
+		SingleH1Rule{},
+		ImageAltRule{},
+		LinkRatioRule{},
+		CanonicalRule{},
+		ViewportRule{},
+		HreflangRule{},
+		StructuredDataRule{},
+		CoreWebVitalsHintRule{},
+	}
+}
+
+// TitleLengthRule checks that <title> is present and within the length
+// search engines typically render in full (roughly 10-60 characters).
+type TitleLengthRule struct{}
+
+func (TitleLengthRule) ID() string  { return "title-length" }
+func (TitleLengthRule) Weight() int { return 10 }
+func (TitleLengthRule) Check(ctx *PageContext) []Finding {
+	title := strings.TrimSpace(ctx.Doc.Find("title").First().Text())
+	switch {
+	case title == "":
+		return []Finding{{RuleID: "title-length", Severity: SeverityFail, Message: "missing <title>"}}
+	case len(title) < 10 || len(title) > 60:
+		return []Finding{{RuleID: "title-length", Severity: SeverityWarn,
+			Message: fmt.Sprintf("title is %d characters, recommended 10-60", len(title))}}
+	default:
+		return nil
+	}
+}
+
+// MetaDescriptionRule checks that a meta description is present and within
+// the length search engines typically show (roughly 50-160 characters).
+type MetaDescriptionRule struct{}
+
+func (MetaDescriptionRule) ID() string  { return "meta-description" }
+func (MetaDescriptionRule) Weight() int { return 8 }
+func (MetaDescriptionRule) Check(ctx *PageContext) []Finding {
+	desc, ok := ctx.Doc.Find(`meta[name="description"]`).First().Attr("content")
+	desc = strings.TrimSpace(desc)
+	switch {
+	case !ok || desc == "":
+		return []Finding{{RuleID: "meta-description", Severity: SeverityFail, Message: "missing meta description"}}
+	case len(desc) < 50 || len(desc) > 160:
+		return []Finding{{RuleID: "meta-description", Severity: SeverityWarn,
+			Message: fmt.Sprintf("meta description is %d characters, recommended 50-160", len(desc))}}
+	default:
+		return nil
+	}
+}
+
+// SingleH1Rule checks that the page has exactly one <h1>.
+type SingleH1Rule struct{}
+
+func (SingleH1Rule) ID() string  { return "single-h1" }
+func (SingleH1Rule) Weight() int { return 6 }
+func (SingleH1Rule) Check(ctx *PageContext) []Finding {
+	n := ctx.Doc.Find("h1").Length()
+	switch {
+	case n == 0:
+		return []Finding{{RuleID: "single-h1", Severity: SeverityFail, Message: "no <h1> found"}}
+	case n > 1:
+		return []Finding{{RuleID: "single-h1", Severity: SeverityWarn,
+			Message: fmt.Sprintf("found %d <h1> elements, expected 1", n)}}
+	default:
+		return nil
+	}
+}
+
+// ImageAltRule checks what fraction of <img> elements have non-empty alt
+// text.
+type ImageAltRule struct{}
+
+func (ImageAltRule) ID() string  { return "image-alt" }
+func (ImageAltRule) Weight() int { return 6 }
+func (ImageAltRule) Check(ctx *PageContext) []Finding {
+	imgs := ctx.Doc.Find("img")
+	total := imgs.Length()
+	if total == 0 {
+		return nil
+	}
+	missing := 0
+	imgs.Each(func(_ int, s *goquery.Selection) {
+		if alt, ok := s.Attr("alt"); !ok || strings.TrimSpace(alt) == "" {
+			missing++
+		}
+	})
+	if missing == 0 {
+		return nil
+	}
+	severity := SeverityWarn
+	if missing == total {
+		severity = SeverityFail
+	}
+	return []Finding{{RuleID: "image-alt", Severity: severity,
+		Message: fmt.Sprintf("%d of %d images are missing alt text", missing, total)}}
+}
+
+// LinkRatioRule flags pages that link almost entirely off-site, which is
+// unusual for a page meant to keep visitors on the site.
+type LinkRatioRule struct{}
+
+func (LinkRatioRule) ID() string  { return "link-ratio" }
+func (LinkRatioRule) Weight() int { return 4 }
+func (LinkRatioRule) Check(ctx *PageContext) []Finding {
+	pageURL, err := url.Parse(ctx.URL)
+	if err != nil {
+		return nil
+	}
+	var internal, external int
+	ctx.Doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		ref, err := url.Parse(href)
+		if err != nil {
+			return
+		}
+		resolved := pageURL.ResolveReference(ref)
+		if resolved.Host == pageURL.Host {
+			internal++
+		} else if resolved.Scheme == "http" || resolved.Scheme == "https" {
+			external++
+		}
+	})
+	total := internal + external
+	if total == 0 || external == 0 {
+		return nil
+	}
+	if float64(external)/float64(total) > 0.9 {
+		return []Finding{{RuleID: "link-ratio", Severity: SeverityWarn,
+			Message: fmt.Sprintf("%d of %d links are external", external, total)}}
+	}
+	return nil
+}
+
+// CanonicalRule checks that the page declares a canonical link and that it
+// is an absolute URL on the page's own host. The canonical's path is not
+// compared with the page's.
+type CanonicalRule struct{}
+
+func (CanonicalRule) ID() string  { return "canonical" }
+func (CanonicalRule) Weight() int { return 8 }
+func (CanonicalRule) Check(ctx *PageContext) []Finding {
+	href, ok := ctx.Doc.Find(`link[rel="canonical"]`).First().Attr("href")
+	if !ok || strings.TrimSpace(href) == "" {
+		return []Finding{{RuleID: "canonical", Severity: SeverityWarn, Message: "missing rel=canonical link"}}
+	}
+	canonical, err := url.Parse(href)
+	if err != nil || !canonical.IsAbs() {
+		return []Finding{{RuleID: "canonical", Severity: SeverityFail, Message: "canonical URL is not absolute"}}
+	}
+	pageURL, err := url.Parse(ctx.URL)
+	if err == nil && canonical.Host != pageURL.Host {
+		return []Finding{{RuleID: "canonical", Severity: SeverityWarn,
+			Message: fmt.Sprintf("canonical host %q does not match page host %q", canonical.Host, pageURL.Host)}}
+	}
+	return nil
+}
+
+// ViewportRule checks for a mobile viewport meta tag.
+type ViewportRule struct{}
+
+func (ViewportRule) ID() string  { return "viewport" }
+func (ViewportRule) Weight() int { return 6 }
+func (ViewportRule) Check(ctx *PageContext) []Finding {
+	if _, ok := ctx.Doc.Find(`meta[name="viewport"]`).First().Attr("content"); !ok {
+		return []Finding{{RuleID: "viewport", Severity: SeverityFail, Message: "missing mobile viewport meta tag"}}
+	}
+	return nil
+}
+
+// HreflangRule checks that a page's hreflang alternate links don't declare
+// the same language more than once, which search engines treat as
+// ambiguous.
+type HreflangRule struct{}
+
+func (HreflangRule) ID() string  { return "hreflang" }
+func (HreflangRule) Weight() int { return 4 }
+func (HreflangRule) Check(ctx *PageContext) []Finding {
+	seen := make(map[string]bool)
+	var dupes []string
+	ctx.Doc.Find(`link[rel="alternate"][hreflang]`).Each(func(_ int, s *goquery.Selection) {
+		lang, _ := s.Attr("hreflang")
+		lang = strings.ToLower(lang)
+		if seen[lang] {
+			dupes = append(dupes, lang)
+		}
+		seen[lang] = true
+	})
+	if len(dupes) == 0 {
+		return nil
+	}
+	return []Finding{{RuleID: "hreflang", Severity: SeverityWarn,
+		Message: fmt.Sprintf("duplicate hreflang values: %s", strings.Join(dupes, ", "))}}
+}
+
+// StructuredDataRule checks that the page exposes at least one JSON-LD or
+// microdata entity.
+type StructuredDataRule struct{}
+
+func (StructuredDataRule) ID() string  { return "structured-data" }
+func (StructuredDataRule) Weight() int { return 6 }
+func (StructuredDataRule) Check(ctx *PageContext) []Finding {
+	if len(ctx.Structured.JSONLD) == 0 && len(ctx.Structured.Microdata) == 0 {
+		return []Finding{{RuleID: "structured-data", Severity: SeverityWarn, Message: "no structured data found"}}
+	}
+	return nil
+}
+
+// CoreWebVitalsHintRule looks for response headers that correlate with good
+// Core Web Vitals: compression, and a cache-control policy that lets
+// repeat views skip the network.
+type CoreWebVitalsHintRule struct{}
+
+func (CoreWebVitalsHintRule) ID() string  { return "core-web-vitals-hints" }
+func (CoreWebVitalsHintRule) Weight() int { return 4 }
+func (CoreWebVitalsHintRule) Check(ctx *PageContext) []Finding {
+	var findings []Finding
+	if enc := ctx.Headers.Get("Content-Encoding"); enc == "" {
+		findings = append(findings, Finding{RuleID: "core-web-vitals-hints", Severity: SeverityWarn,
+			Message: "response is not compressed (no Content-Encoding)"})
+	}
+	if cc := ctx.Headers.Get("Cache-Control"); cc == "" {
+		findings = append(findings, Finding{RuleID: "core-web-vitals-hints", Severity: SeverityWarn,
+			Message: "response has no Cache-Control header"})
+	}
+	return findings
+}
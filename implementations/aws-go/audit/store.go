@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bwatson/seo-solver/aws-go/store/dynamo"
+)
+
+// SaveSiteScore persists a site-level rollup for site on the UTC calendar
+// day containing at, keyed by (site, date) so a scheduled Lambda can later
+// load consecutive days and DiffReport between them.
+func SaveSiteScore(ctx context.Context, repo *dynamo.Repository[dynamo.SiteScore], site string, at time.Time, score, pageCount int) error {
+	day := at.UTC().Truncate(24 * time.Hour)
+	err := repo.Put(ctx, site, dynamo.RollupURLKey, day, dynamo.SiteScore{
+		Site:      site,
+		Date:      day.Format("2006-01-02"),
+		Timestamp: day,
+		Score:     score,
+		PageCount: pageCount,
+	})
+	if err != nil {
+		return fmt.Errorf("audit: save site score: %w", err)
+	}
+	return nil
+}
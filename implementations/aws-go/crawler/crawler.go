@@ -0,0 +1,266 @@
+// Package crawler implements a bounded, resumable recursive crawl: seed
+// URLs are fetched, links are extracted and normalized, and newly
+// discovered same-crawl links are enqueued for a pool of worker goroutines,
+// subject to a robots.txt policy, a per-host rate limit, and a max-depth /
+// max-pages budget.
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/bwatson/seo-solver/aws-go/crawler/robots"
+	"github.com/bwatson/seo-solver/aws-go/store/dynamo"
+	"github.com/bwatson/seo-solver/aws-go/telemetry"
+)
+
+// Config bounds and tunes a single crawl.
+type Config struct {
+	MaxDepth  int    // 0 means "seeds only"
+	MaxPages  int    // total pages fetched across the whole crawl
+	Workers   int    // concurrent fetch goroutines
+	UserAgent string // sent as the HTTP User-Agent and used to select the robots.txt group
+}
+
+// Summary reports what a single Crawl call did.
+type Summary struct {
+	PagesCrawled int
+	PagesSkipped int
+	Errors       int
+}
+
+// Crawler fetches, parses, and persists pages for a crawl, resuming across
+// invocations via the shared VisitedStore.
+type Crawler struct {
+	cfg     Config
+	http    *http.Client
+	robots  *robots.Cache
+	visited *VisitedStore
+	results *dynamo.Repository[dynamo.CrawlResult]
+	limiter *hostLimiter
+	logger  *telemetry.Logger
+}
+
+// New returns a Crawler that fetches with httpClient, checks robots.txt via
+// robotsCache, deduplicates visits via visited, and writes page results
+// through results. logger may be nil, in which case the crawl runs without
+// emitting log lines.
+func New(cfg Config, httpClient *http.Client, robotsCache *robots.Cache, visited *VisitedStore, results *dynamo.Repository[dynamo.CrawlResult], logger *telemetry.Logger) *Crawler {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	return &Crawler{
+		cfg:     cfg,
+		http:    httpClient,
+		robots:  robotsCache,
+		visited: visited,
+		results: results,
+		limiter: newHostLimiter(time.Second),
+		logger:  logger,
+	}
+}
+
+// job is one queued (url, depth) pair awaiting a fetch.
+type job struct {
+	url   string
+	depth int
+}
+
+// Crawl runs a bounded crawl of site starting from seeds, writing a
+// dynamo.CrawlResult for every page fetched. It returns once every
+// reachable URL within the configured depth and page budget has been
+// processed, or the budget is exhausted.
+func (c *Crawler) Crawl(ctx context.Context, crawlID, site string, seeds []string) (Summary, error) {
+	ctx = telemetry.WithCrawlID(ctx, crawlID)
+	var enqueueWG sync.WaitGroup
+	q := newJobQueue(ctx, func(job) { enqueueWG.Done() })
+
+	var mu sync.Mutex
+	summary := Summary{}
+	budgetExhausted := false
+	enqueued := 0
+
+	var enqueue func(j job)
+	enqueue = func(j job) {
+		mu.Lock()
+		if budgetExhausted || enqueued >= c.cfg.MaxPages {
+			budgetExhausted = true
+			mu.Unlock()
+			return
+		}
+		enqueued++
+		mu.Unlock()
+		enqueueWG.Add(1)
+		if !q.push(j) {
+			enqueueWG.Done()
+		}
+	}
+
+	for _, seed := range seeds {
+		enqueue(job{url: seed, depth: 0})
+	}
+
+	go func() {
+		enqueueWG.Wait()
+		q.close()
+	}()
+
+	var workers sync.WaitGroup
+	for i := 0; i < c.cfg.Workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for j := range q.jobs() {
+				c.process(ctx, crawlID, site, j, &mu, &summary, enqueue)
+				enqueueWG.Done()
+			}
+		}()
+	}
+	workers.Wait()
+
+	return summary, nil
+}
+
+func (c *Crawler) process(ctx context.Context, crawlID, site string, j job, mu *sync.Mutex, summary *Summary, enqueue func(job)) {
+	visited, err := c.visited.IsVisited(ctx, crawlID, j.url)
+	if err != nil {
+		mu.Lock()
+		summary.Errors++
+		mu.Unlock()
+		return
+	}
+	if visited {
+		mu.Lock()
+		summary.PagesSkipped++
+		mu.Unlock()
+		return
+	}
+
+	u, err := url.Parse(j.url)
+	if err != nil {
+		mu.Lock()
+		summary.Errors++
+		mu.Unlock()
+		return
+	}
+
+	rules, err := c.robots.Get(ctx, u.Scheme, u.Host, c.cfg.UserAgent)
+	if err != nil || !rules.Allowed(u.EscapedPath()) {
+		mu.Lock()
+		summary.PagesSkipped++
+		mu.Unlock()
+		return
+	}
+
+	if err := c.limiter.wait(ctx, u.Host, rules.CrawlDelay()); err != nil {
+		mu.Lock()
+		summary.Errors++
+		mu.Unlock()
+		return
+	}
+
+	fetchStart := time.Now()
+	result, links, err := c.fetchAndParse(ctx, u)
+	c.logger.Info(ctx, "crawler fetch", telemetry.Fields{
+		"url":       j.url,
+		"depth":     j.depth,
+		"latencyMs": time.Since(fetchStart).Milliseconds(),
+		"status":    result.StatusCode,
+	})
+	if err != nil {
+		mu.Lock()
+		summary.Errors++
+		mu.Unlock()
+		return
+	}
+
+	now := time.Now()
+	result.Site = site
+	result.URL = j.url
+	result.Timestamp = now
+	if err := c.results.Put(ctx, site, j.url, now, result); err != nil {
+		mu.Lock()
+		summary.Errors++
+		mu.Unlock()
+		return
+	}
+	if err := c.visited.MarkVisited(ctx, crawlID, j.url); err != nil {
+		mu.Lock()
+		summary.Errors++
+		mu.Unlock()
+		return
+	}
+
+	mu.Lock()
+	summary.PagesCrawled++
+	mu.Unlock()
+
+	if j.depth >= c.cfg.MaxDepth {
+		return
+	}
+	for _, link := range links {
+		enqueue(job{url: link, depth: j.depth + 1})
+	}
+}
+
+// fetchAndParse retrieves u and builds the CrawlResult and outbound link
+// list for it. It returns a populated CrawlResult for every response it
+// receives, even non-2xx ones, so broken pages still show up in the audit
+// trail; a request that gets no response returns an empty CrawlResult and
+// an error.
+func (c *Crawler) fetchAndParse(ctx context.Context, u *url.URL) (dynamo.CrawlResult, []string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return dynamo.CrawlResult{}, nil, fmt.Errorf("crawler: build request: %w", err)
+	}
+	req.Header.Set("User-Agent", c.cfg.UserAgent)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return dynamo.CrawlResult{}, nil, fmt.Errorf("crawler: fetch %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	result := dynamo.CrawlResult{StatusCode: resp.StatusCode}
+	if resp.StatusCode >= 300 {
+		return result, nil, nil
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return result, nil, fmt.Errorf("crawler: parse %s: %w", u, err)
+	}
+
+	result.Title = strings.TrimSpace(doc.Find("title").First().Text())
+	result.MetaDescription = metaContent(doc, "description")
+	result.Canonical, _ = doc.Find(`link[rel="canonical"]`).First().Attr("href")
+	result.HeadingCounts = headingCounts(doc)
+	result.WordCount = len(strings.Fields(doc.Find("body").Text()))
+
+	links := extractLinks(doc, u)
+	result.OutboundLinks = links
+	return result, links, nil
+}
+
+func metaContent(doc *goquery.Document, name string) string {
+	content, _ := doc.Find(fmt.Sprintf(`meta[name="%s"]`, name)).First().Attr("content")
+	return content
+}
+
+func headingCounts(doc *goquery.Document) map[string]int {
+	counts := make(map[string]int, 6)
+	for i := 1; i <= 6; i++ {
+		tag := fmt.Sprintf("h%d", i)
+		if n := doc.Find(tag).Length(); n > 0 {
+			counts[tag] = n
+		}
+	}
+	return counts
+}
@@ -0,0 +1,208 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"github.com/bwatson/seo-solver/aws-go/crawler/robots"
+	"github.com/bwatson/seo-solver/aws-go/store/dynamo"
+)
+
+// fakeRoundTripper serves the page fetches a Crawl makes (by URL) and a
+// harmless response to everything else a crawl touches over HTTP: robots.txt
+// lookups (404, so everything is allowed) and every DynamoDB call
+// VisitedStore/dynamo.Repository issue underneath (GetItem reports "not
+// visited", every write succeeds). This is enough to drive Crawl end-to-end
+// without a network or a real table.
+type fakeRoundTripper struct {
+	mu    sync.Mutex
+	pages map[string]string
+
+	// pageDelay, if set, is slept before serving any page whose host has
+	// pageDelayHostPrefix as a prefix, simulating a slow fetch so a test can
+	// cancel mid-crawl while other discovered links are still sitting
+	// unfetched in the dispatcher.
+	pageDelay           time.Duration
+	pageDelayHostPrefix string
+}
+
+func (f *fakeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("X-Amz-Target") != "" {
+		return jsonResponse(`{}`), nil
+	}
+	if strings.HasSuffix(req.URL.Path, "/robots.txt") {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewReader(nil)), Header: http.Header{}}, nil
+	}
+	if f.pageDelay > 0 && strings.HasPrefix(req.URL.Hostname(), f.pageDelayHostPrefix) {
+		time.Sleep(f.pageDelay)
+	}
+	f.mu.Lock()
+	body, ok := f.pages[req.URL.String()]
+	f.mu.Unlock()
+	if !ok {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewReader(nil)), Header: http.Header{}}, nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Header:     http.Header{"Content-Type": []string{"text/html"}},
+	}, nil
+}
+
+func jsonResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+		Header:     http.Header{"Content-Type": []string{"application/x-amz-json-1.0"}},
+	}
+}
+
+// newTestCrawler wires a Crawler up to transport for both page fetches and
+// robots.txt lookups, and to a DynamoDB client pointed at the same fake
+// transport for VisitedStore and the results Repository.
+func newTestCrawler(t *testing.T, cfg Config, transport http.RoundTripper) *Crawler {
+	t.Helper()
+	httpClient := &http.Client{Transport: transport}
+
+	dynamoClient := dynamodb.NewFromConfig(aws.Config{
+		Region:      "us-east-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  httpClient,
+	})
+	table := dynamo.NewTable(dynamoClient, "test-table")
+	visited := NewVisitedStore(dynamoClient, "test-visited", time.Hour)
+	results := dynamo.NewRepository[dynamo.CrawlResult](table)
+	robotsCache := robots.NewCache(httpClient, time.Hour)
+
+	return New(cfg, httpClient, robotsCache, visited, results, nil)
+}
+
+// TestCrawlFollowsLinksAcrossHosts drives a small link graph end-to-end: a
+// seed page links to more pages than there are workers, on distinct hosts so
+// the built-in per-host rate limit can't slow the test down. It exercises
+// the orchestration in Crawl/process together — recursive enqueue, the
+// visited/robots/fetch/persist sequence, and summary accounting — rather
+// than any one helper in isolation.
+func TestCrawlFollowsLinksAcrossHosts(t *testing.T) {
+	const childCount = 8
+	var links strings.Builder
+	for i := 0; i < childCount; i++ {
+		fmt.Fprintf(&links, `<a href="http://child%d.test/">child</a>`, i)
+	}
+	pages := map[string]string{
+		"http://root.test/": "<html><body>" + links.String() + "</body></html>",
+	}
+	for i := 0; i < childCount; i++ {
+		pages[fmt.Sprintf("http://child%d.test/", i)] = "<html><body>leaf</body></html>"
+	}
+
+	transport := &fakeRoundTripper{pages: pages}
+	c := newTestCrawler(t, Config{MaxDepth: 1, MaxPages: 100, Workers: 3, UserAgent: "seo-solver-test"}, transport)
+
+	done := make(chan Summary, 1)
+	go func() {
+		// Discard the error and let the caller assert on the summary only:
+		// t's deadline may fire (and the test goroutine exit via
+		// t.Fatal/runtime.Goexit) before this goroutine's Crawl call
+		// returns, and calling t.Error after the test has completed panics
+		// the whole binary.
+		summary, _ := c.Crawl(context.Background(), "crawl-1", "example.com", []string{"http://root.test/"})
+		done <- summary
+	}()
+
+	select {
+	case summary := <-done:
+		if summary.PagesCrawled != childCount+1 {
+			t.Errorf("PagesCrawled = %d, want %d", summary.PagesCrawled, childCount+1)
+		}
+		if summary.Errors != 0 {
+			t.Errorf("Errors = %d, want 0 (summary: %+v)", summary.Errors, summary)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Crawl did not return; the recursive-enqueue path deadlocked")
+	}
+}
+
+// TestCrawlDoesNotLeakGoroutineWhenContextCanceledMidCrawl guards against a
+// goroutine leak that doesn't keep Crawl itself from returning: once ctx is
+// canceled, the dispatcher closes jobs() unconditionally, so the worker pool
+// drains and Crawl's own call returns either way. What leaked was the
+// separate background goroutine ("enqueueWG.Wait(); q.close()") started
+// inside Crawl — if a page's discovered links are pushed (incrementing
+// enqueueWG) but never delivered to a worker before ctx cancels, nothing
+// ever called the matching enqueueWG.Done(), so that goroutine blocked in
+// Wait() forever. On a warm, reused Lambda execution environment this
+// accumulates one leaked goroutine per timed-out invocation.
+func TestCrawlDoesNotLeakGoroutineWhenContextCanceledMidCrawl(t *testing.T) {
+	const childCount = 50
+	var links strings.Builder
+	for i := 0; i < childCount; i++ {
+		fmt.Fprintf(&links, `<a href="http://child%d.test/">child</a>`, i)
+	}
+	pages := map[string]string{
+		"http://root.test/": "<html><body>" + links.String() + "</body></html>",
+	}
+	for i := 0; i < childCount; i++ {
+		pages[fmt.Sprintf("http://child%d.test/", i)] = "<html><body>leaf</body></html>"
+	}
+
+	// The root page resolves instantly, pushing all 50 child jobs into the
+	// dispatcher. Slowing down child fetches, combined with a single
+	// worker, guarantees most of those 50 are still sitting undelivered in
+	// the dispatcher's buffer when ctx cancels.
+	transport := &fakeRoundTripper{pages: pages, pageDelay: 200 * time.Millisecond, pageDelayHostPrefix: "child"}
+	c := newTestCrawler(t, Config{MaxDepth: 1, MaxPages: 1000, Workers: 1, UserAgent: "seo-solver-test"}, transport)
+
+	baseline := goroutineCountSettled(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = c.Crawl(ctx, "crawl-2", "example.com", []string{"http://root.test/"})
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Crawl did not return after ctx was canceled mid-crawl")
+	}
+
+	after := goroutineCountSettled(t)
+	if after > baseline {
+		t.Errorf("goroutine count = %d after a canceled crawl settled, want <= baseline %d; the enqueueWG.Wait()-then-close goroutine likely leaked", after, baseline)
+	}
+}
+
+// goroutineCountSettled polls runtime.NumGoroutine until it stops dropping
+// (background goroutines from a prior subtest can take a moment to exit) or
+// a short deadline passes, and returns the last observed count.
+func goroutineCountSettled(t *testing.T) int {
+	t.Helper()
+	count := runtime.NumGoroutine()
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for time.Now().Before(deadline) {
+		runtime.Gosched()
+		time.Sleep(10 * time.Millisecond)
+		next := runtime.NumGoroutine()
+		if next >= count {
+			break
+		}
+		count = next
+	}
+	return count
+}
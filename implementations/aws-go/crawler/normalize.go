@@ -0,0 +1,64 @@
+package crawler
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// normalizeLink resolves href against base, strips any fragment, and
+// rejects anything that isn't a fetchable http(s) URL. ok is false for
+// mailto:, javascript:, tel:, empty hrefs, and unparsable URLs.
+func normalizeLink(base *url.URL, href string) (normalized string, ok bool) {
+	href = strings.TrimSpace(href)
+	if href == "" {
+		return "", false
+	}
+	ref, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+	resolved := base.ResolveReference(ref)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return "", false
+	}
+	resolved.Fragment = ""
+	return resolved.String(), true
+}
+
+// extractLinks returns the normalized, deduplicated set of http(s) links
+// found in doc's <a href> elements, skipping any tagged rel="nofollow".
+func extractLinks(doc *goquery.Document, base *url.URL) []string {
+	seen := make(map[string]bool)
+	var links []string
+	doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		if hasNofollow(s) {
+			return
+		}
+		href, ok := s.Attr("href")
+		if !ok {
+			return
+		}
+		normalized, ok := normalizeLink(base, href)
+		if !ok || seen[normalized] {
+			return
+		}
+		seen[normalized] = true
+		links = append(links, normalized)
+	})
+	return links
+}
+
+func hasNofollow(s *goquery.Selection) bool {
+	rel, ok := s.Attr("rel")
+	if !ok {
+		return false
+	}
+	for _, tok := range strings.Fields(rel) {
+		if strings.EqualFold(tok, "nofollow") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package crawler
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestNormalizeLink(t *testing.T) {
+	base, err := url.Parse("https://example.com/blog/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		href    string
+		wantURL string
+		wantOK  bool
+	}{
+		{"/about", "https://example.com/about", true},
+		{"post-1", "https://example.com/blog/post-1", true},
+		{"https://other.com/x#frag", "https://other.com/x", true},
+		{"mailto:a@b.com", "", false},
+		{"javascript:void(0)", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := normalizeLink(base, tt.href)
+		if ok != tt.wantOK || got != tt.wantURL {
+			t.Errorf("normalizeLink(%q) = (%q, %v), want (%q, %v)", tt.href, got, ok, tt.wantURL, tt.wantOK)
+		}
+	}
+}
+
+func TestExtractLinksSkipsNofollow(t *testing.T) {
+	html := `<html><body>
+		<a href="/a">a</a>
+		<a href="/b" rel="nofollow">b</a>
+		<a href="/a">dup</a>
+	</body></html>`
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, _ := url.Parse("https://example.com/")
+
+	got := extractLinks(doc, base)
+	want := []string{"https://example.com/a"}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("extractLinks() = %v, want %v", got, want)
+	}
+}
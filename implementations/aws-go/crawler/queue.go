@@ -0,0 +1,109 @@
+package crawler
+
+import "context"
+
+// jobQueue is an unbounded FIFO of jobs fed by a single dispatcher goroutine.
+// Unlike a plain buffered channel, push never blocks on a worker being free
+// to receive from jobs() — it only hands off to the dispatcher, which is
+// always ready to accept. This matters because workers push new jobs (the
+// links found on the page they just fetched) from inside the very loop that
+// drains jobs(): with a bounded channel shared between producers and
+// consumers, a page with more outbound links than the channel's free
+// capacity would block every worker on a send with no goroutine left in the
+// receive loop to unblock it.
+//
+// The queue can also stop early, mid-crawl, when ctx is done. Any job still
+// sitting in the dispatcher's buffer at that point is handed to onDiscard
+// instead of being silently dropped, so a caller tracking outstanding jobs
+// (e.g. via a sync.WaitGroup incremented on push) always gets a matching
+// resolution for every job it pushed, even ones that never reached a
+// worker.
+type jobQueue struct {
+	in        chan job
+	out       chan job
+	stop      chan struct{}
+	closed    chan struct{}
+	onDiscard func(job)
+}
+
+// newJobQueue starts the dispatcher goroutine and returns a ready queue. The
+// dispatcher exits, closing jobs(), when ctx is done or close is called.
+// onDiscard, if non-nil, is called once for every job still buffered and
+// undelivered at that point; it is never called for a job push itself
+// rejects (see push).
+func newJobQueue(ctx context.Context, onDiscard func(job)) *jobQueue {
+	q := &jobQueue{
+		in:        make(chan job),
+		out:       make(chan job),
+		stop:      make(chan struct{}),
+		closed:    make(chan struct{}),
+		onDiscard: onDiscard,
+	}
+	go q.run(ctx)
+	return q
+}
+
+func (q *jobQueue) run(ctx context.Context) {
+	defer close(q.closed)
+	defer close(q.out)
+
+	var pending []job
+	for {
+		var next job
+		var out chan job
+		if len(pending) > 0 {
+			next = pending[0]
+			out = q.out
+		}
+		select {
+		case j := <-q.in:
+			pending = append(pending, j)
+		case out <- next:
+			pending = pending[1:]
+		case <-q.stop:
+			q.discard(pending)
+			return
+		case <-ctx.Done():
+			q.discard(pending)
+			return
+		}
+	}
+}
+
+func (q *jobQueue) discard(pending []job) {
+	if q.onDiscard == nil {
+		return
+	}
+	for _, j := range pending {
+		q.onDiscard(j)
+	}
+}
+
+// push enqueues j. It blocks only until the dispatcher goroutine accepts the
+// handoff, never until a worker is free to process it. It reports whether j
+// was accepted: once the queue has already stopped, push rejects j instead
+// of accepting and immediately discarding it, so the caller — not
+// onDiscard — is responsible for resolving any bookkeeping it attached
+// before the push (onDiscard only fires for jobs accepted before the stop).
+func (q *jobQueue) push(j job) bool {
+	select {
+	case q.in <- j:
+		return true
+	case <-q.closed:
+		return false
+	}
+}
+
+// jobs returns the channel workers range over. It closes once the queue is
+// stopped and every buffered job has either been handed to a worker or
+// passed to onDiscard.
+func (q *jobQueue) jobs() <-chan job {
+	return q.out
+}
+
+// close stops the dispatcher, which closes jobs() right away; any job still
+// buffered and undelivered is passed to onDiscard rather than handed to a
+// worker.
+func (q *jobQueue) close() {
+	close(q.stop)
+}
@@ -0,0 +1,52 @@
+package crawler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestJobQueueDoesNotDeadlockOnBurstyEnqueue reproduces a page whose
+// outbound links outnumber any fixed buffer: the same goroutine that is
+// ranging over jobs() pushes a burst of new jobs from inside that loop,
+// before it returns to receive again. A bounded channel shared between
+// producers and consumers deadlocks in exactly this shape once the burst
+// exceeds its free capacity, because the only goroutine that could drain it
+// is the one blocked trying to send. The queue must absorb the burst
+// without blocking the pushing goroutine.
+func TestJobQueueDoesNotDeadlockOnBurstyEnqueue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const linksPerPage = 50
+
+	q := newJobQueue(ctx, nil)
+	q.push(job{url: "seed"})
+
+	processed := 0
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for j := range q.jobs() {
+			processed++
+			if j.url == "seed" {
+				for i := 0; i < linksPerPage; i++ {
+					q.push(job{url: "link"})
+				}
+				continue
+			}
+			if processed == linksPerPage+1 {
+				q.close()
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("jobQueue deadlocked when a burst of pushes exceeded any fixed buffer size")
+	}
+	if processed != linksPerPage+1 {
+		t.Errorf("processed %d jobs, want %d", processed, linksPerPage+1)
+	}
+}
@@ -0,0 +1,58 @@
+package crawler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// hostLimiter enforces a minimum delay between requests to the same host,
+// defaulting to a fixed interval but honoring a longer per-request delay
+// (e.g. a robots.txt Crawl-delay) when one is supplied.
+type hostLimiter struct {
+	defaultDelay time.Duration
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+// newHostLimiter returns a hostLimiter that waits at least defaultDelay
+// between requests to a given host unless wait is called with a longer
+// explicit delay.
+func newHostLimiter(defaultDelay time.Duration) *hostLimiter {
+	return &hostLimiter{
+		defaultDelay: defaultDelay,
+		next:         make(map[string]time.Time),
+	}
+}
+
+// wait reserves this host's next slot and blocks until it arrives. delay
+// overrides the limiter's default when it is longer. It returns early with
+// ctx.Err() if ctx is canceled while waiting.
+func (l *hostLimiter) wait(ctx context.Context, host string, delay time.Duration) error {
+	if delay < l.defaultDelay {
+		delay = l.defaultDelay
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	slot, scheduled := l.next[host]
+	if !scheduled || now.After(slot) {
+		slot = now
+	}
+	l.next[host] = slot.Add(delay)
+	l.mu.Unlock()
+
+	wait := slot.Sub(now)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,53 @@
+package crawler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHostLimiterSerializesPerHost(t *testing.T) {
+	l := newHostLimiter(20 * time.Millisecond)
+	ctx := context.Background()
+
+	start := time.Now()
+	if err := l.wait(ctx, "example.com", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.wait(ctx, "example.com", 0); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+		t.Errorf("second wait returned after %v, want >= 20ms", elapsed)
+	}
+}
+
+func TestHostLimiterIndependentHosts(t *testing.T) {
+	l := newHostLimiter(50 * time.Millisecond)
+	ctx := context.Background()
+
+	start := time.Now()
+	if err := l.wait(ctx, "a.com", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.wait(ctx, "b.com", 0); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed >= 50*time.Millisecond {
+		t.Errorf("wait on a different host took %v, want < 50ms", elapsed)
+	}
+}
+
+func TestHostLimiterRespectsCtxCancel(t *testing.T) {
+	l := newHostLimiter(time.Hour)
+	ctx := context.Background()
+	if err := l.wait(ctx, "example.com", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.wait(cancelCtx, "example.com", 0); err == nil {
+		t.Error("wait() with canceled context = nil error, want non-nil")
+	}
+}
@@ -0,0 +1,216 @@
+// Package robots fetches, parses, and caches robots.txt so the crawler can
+// honor Disallow and Crawl-delay directives for the User-agent it crawls
+// under.
+package robots
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rules is the parsed rule set for a single User-agent group.
+type Rules struct {
+	allow      []string
+	disallow   []string
+	crawlDelay time.Duration
+}
+
+// Allowed reports whether path may be fetched under these rules. It applies
+// the longest-matching-prefix-wins convention used by major crawlers: the
+// most specific Allow or Disallow rule decides, and Allow wins ties.
+func (r *Rules) Allowed(path string) bool {
+	best := -1
+	allowed := true
+	check := func(patterns []string, isAllow bool, wins func(matchLen, best int) bool) {
+		for _, p := range patterns {
+			if p == "" {
+				continue
+			}
+			if strings.HasPrefix(path, p) && wins(len(p), best) {
+				best = len(p)
+				allowed = isAllow
+			}
+		}
+	}
+	// Disallow only overrides a strictly more specific match so far;
+	// Allow also overrides an equally specific one, so a tie goes to Allow.
+	check(r.disallow, false, func(matchLen, best int) bool { return matchLen > best })
+	check(r.allow, true, func(matchLen, best int) bool { return matchLen >= best })
+	return allowed
+}
+
+// CrawlDelay returns the Crawl-delay directive for this group, or 0 if none
+// was specified.
+func (r *Rules) CrawlDelay() time.Duration {
+	return r.crawlDelay
+}
+
+// Parse reads a robots.txt document and returns the rules applicable to
+// userAgent, falling back to the "*" group when no group matches exactly.
+func Parse(r io.Reader, userAgent string) (*Rules, error) {
+	groups, err := parseGroups(r)
+	if err != nil {
+		return nil, err
+	}
+	if g, ok := groups[strings.ToLower(userAgent)]; ok {
+		return g, nil
+	}
+	if g, ok := groups["*"]; ok {
+		return g, nil
+	}
+	return &Rules{}, nil
+}
+
+// parseGroups splits robots.txt into per-User-agent rule groups. A group
+// starts at each run of "User-agent:" lines and extends to the next one;
+// the same Allow/Disallow/Crawl-delay lines apply to every agent named in
+// that run, matching the de facto robots.txt convention.
+func parseGroups(r io.Reader) (map[string]*Rules, error) {
+	groups := make(map[string]*Rules)
+	var current []string
+
+	scanner := bufio.NewScanner(r)
+	sawDirectiveSinceAgent := true
+	for scanner.Scan() {
+		line := stripComment(scanner.Text())
+		if line == "" {
+			continue
+		}
+		field, value, ok := splitDirective(line)
+		if !ok {
+			continue
+		}
+		switch field {
+		case "user-agent":
+			if sawDirectiveSinceAgent {
+				current = nil
+			}
+			agent := strings.ToLower(value)
+			current = append(current, agent)
+			for _, a := range current {
+				if groups[a] == nil {
+					groups[a] = &Rules{}
+				}
+			}
+			sawDirectiveSinceAgent = false
+		case "allow":
+			sawDirectiveSinceAgent = true
+			for _, a := range current {
+				groups[a].allow = append(groups[a].allow, value)
+			}
+		case "disallow":
+			sawDirectiveSinceAgent = true
+			for _, a := range current {
+				groups[a].disallow = append(groups[a].disallow, value)
+			}
+		case "crawl-delay":
+			sawDirectiveSinceAgent = true
+			secs, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			delay := time.Duration(secs * float64(time.Second))
+			for _, a := range current {
+				groups[a].crawlDelay = delay
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("robots: scan: %w", err)
+	}
+	return groups, nil
+}
+
+func stripComment(line string) string {
+	if i := strings.IndexByte(line, '#'); i >= 0 {
+		line = line[:i]
+	}
+	return strings.TrimSpace(line)
+}
+
+func splitDirective(line string) (field, value string, ok bool) {
+	i := strings.IndexByte(line, ':')
+	if i < 0 {
+		return "", "", false
+	}
+	return strings.ToLower(strings.TrimSpace(line[:i])), strings.TrimSpace(line[i+1:]), true
+}
+
+// cacheEntry is a cached, parsed robots.txt for one host.
+type cacheEntry struct {
+	rules     *Rules
+	fetchedAt time.Time
+}
+
+// Cache fetches and caches robots.txt per host and User-agent for TTL, so a crawl with many
+// pages on the same site issues one robots.txt request per host instead of
+// one per page.
+type Cache struct {
+	client *http.Client
+	ttl    time.Duration
+
+	mu     sync.Mutex
+	byHost map[string]cacheEntry
+}
+
+// NewCache returns a Cache that fetches with client and caches entries for
+// ttl before refetching.
+func NewCache(client *http.Client, ttl time.Duration) *Cache {
+	return &Cache{
+		client: client,
+		ttl:    ttl,
+		byHost: make(map[string]cacheEntry),
+	}
+}
+
+// Get returns the Rules for userAgent on the host identified by scheme and
+// host, fetching and caching robots.txt on first use. A fetch failure or a
+// non-200 response is treated as "no rules" (everything allowed), matching
+// how crawlers conventionally handle a missing robots.txt.
+func (c *Cache) Get(ctx context.Context, scheme, host, userAgent string) (*Rules, error) {
+	base := scheme + "://" + host
+	key := base + " " + strings.ToLower(userAgent)
+	c.mu.Lock()
+	entry, ok := c.byHost[key]
+	c.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < c.ttl {
+		return entry.rules, nil
+	}
+
+	rules := c.fetch(ctx, base, userAgent)
+	c.mu.Lock()
+	c.byHost[key] = cacheEntry{rules: rules, fetchedAt: time.Now()}
+	c.mu.Unlock()
+	return rules, nil
+}
+
+func (c *Cache) fetch(ctx context.Context, base, userAgent string) *Rules {
+	u := base + "/robots.txt"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return &Rules{}
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return &Rules{}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return &Rules{}
+	}
+
+	rules, err := Parse(resp.Body, userAgent)
+	if err != nil {
+		return &Rules{}
+	}
+	return rules
+}
@@ -0,0 +1,63 @@
+package robots
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const robotsTxt = `
+User-agent: *
+Disallow: /private
+Allow: /private/public-page
+Crawl-delay: 2
+
+User-agent: seo-solver-bot
+Disallow: /
+`
+
+func TestParseSelectsExactGroup(t *testing.T) {
+	rules, err := Parse(strings.NewReader(robotsTxt), "seo-solver-bot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules.Allowed("/anything") {
+		t.Error("seo-solver-bot should be disallowed from /anything")
+	}
+}
+
+func TestParseFallsBackToWildcard(t *testing.T) {
+	rules, err := Parse(strings.NewReader(robotsTxt), "some-other-bot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules.Allowed("/private/secret") {
+		t.Error("/private/secret should be disallowed")
+	}
+	if !rules.Allowed("/private/public-page") {
+		t.Error("/private/public-page should be allowed (more specific Allow)")
+	}
+	if !rules.Allowed("/about") {
+		t.Error("/about should be allowed")
+	}
+	if rules.CrawlDelay() != 2*time.Second {
+		t.Errorf("CrawlDelay() = %v, want 2s", rules.CrawlDelay())
+	}
+}
+
+func TestAllowedEqualLengthTieFavorsAllow(t *testing.T) {
+	rules := &Rules{disallow: []string{"/page"}, allow: []string{"/page"}}
+	if !rules.Allowed("/page") {
+		t.Error("an Allow and a Disallow of equal length should resolve to allowed")
+	}
+}
+
+func TestParseNoMatchAllowsEverything(t *testing.T) {
+	rules, err := Parse(strings.NewReader(""), "any-bot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rules.Allowed("/anything") {
+		t.Error("empty robots.txt should allow everything")
+	}
+}
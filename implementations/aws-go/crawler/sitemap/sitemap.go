@@ -0,0 +1,127 @@
+// Package sitemap discovers crawl seed URLs from sitemap.xml documents,
+// including sitemap index files that point at further sitemaps.
+package sitemap
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxIndexDepth bounds how many levels of sitemap index nesting Discover
+// will follow, guarding against a misconfigured or malicious index cycle.
+const maxIndexDepth = 5
+
+// URL is one entry from a <urlset>, with LastMod parsed when present and
+// well-formed; otherwise it is the zero time.
+type URL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+// urlsetDoc and sitemapIndexDoc are unmarshaled independently from the same
+// document body; encoding/xml only matches declared child elements, so a
+// urlset document simply yields zero Sitemaps and vice versa, with no need
+// to branch on the root element name.
+type urlsetDoc struct {
+	URLs []struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod"`
+	} `xml:"url"`
+}
+
+type sitemapIndexDoc struct {
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// Parser fetches and parses sitemap documents over HTTP.
+type Parser struct {
+	client *http.Client
+}
+
+// NewParser returns a Parser that fetches with client.
+func NewParser(client *http.Client) *Parser {
+	return &Parser{client: client}
+}
+
+// Discover fetches sitemapURL and returns every URL it names, recursively
+// expanding sitemap index files up to maxIndexDepth levels deep.
+func (p *Parser) Discover(ctx context.Context, sitemapURL string) ([]URL, error) {
+	return p.discover(ctx, sitemapURL, 0)
+}
+
+func (p *Parser) discover(ctx context.Context, sitemapURL string, depth int) ([]URL, error) {
+	if depth >= maxIndexDepth {
+		return nil, fmt.Errorf("sitemap: %s: index nesting exceeds %d levels", sitemapURL, maxIndexDepth)
+	}
+
+	body, err := p.fetch(ctx, sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var index sitemapIndexDoc
+	if err := xml.Unmarshal(body, &index); err != nil {
+		return nil, fmt.Errorf("sitemap: %s: parse: %w", sitemapURL, err)
+	}
+	if len(index.Sitemaps) > 0 {
+		var urls []URL
+		for _, s := range index.Sitemaps {
+			children, err := p.discover(ctx, s.Loc, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			urls = append(urls, children...)
+		}
+		return urls, nil
+	}
+
+	var doc urlsetDoc
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("sitemap: %s: parse: %w", sitemapURL, err)
+	}
+	urls := make([]URL, 0, len(doc.URLs))
+	for _, u := range doc.URLs {
+		urls = append(urls, URL{
+			Loc:     u.Loc,
+			LastMod: parseLastMod(u.LastMod),
+		})
+	}
+	return urls, nil
+}
+
+func (p *Parser) fetch(ctx context.Context, sitemapURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sitemapURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("sitemap: %s: build request: %w", sitemapURL, err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sitemap: %s: fetch: %w", sitemapURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("sitemap: %s: unexpected status %d", sitemapURL, resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("sitemap: %s: read body: %w", sitemapURL, err)
+	}
+	return body, nil
+}
+
+// parseLastMod parses the handful of lastmod formats sitemaps.org allows
+// (full timestamp or plain date), returning the zero time for anything else.
+func parseLastMod(s string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
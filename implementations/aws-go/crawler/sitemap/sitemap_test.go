@@ -0,0 +1,58 @@
+package sitemap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiscoverURLSet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<?xml version="1.0"?>
+		<urlset>
+			<url><loc>https://example.com/a</loc><lastmod>2026-01-02</lastmod></url>
+			<url><loc>https://example.com/b</loc></url>
+		</urlset>`))
+	}))
+	defer srv.Close()
+
+	urls, err := NewParser(srv.Client()).Discover(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(urls) != 2 {
+		t.Fatalf("Discover() returned %d URLs, want 2", len(urls))
+	}
+	if urls[0].Loc != "https://example.com/a" || urls[0].LastMod.IsZero() {
+		t.Errorf("unexpected first URL: %+v", urls[0])
+	}
+}
+
+func TestDiscoverSitemapIndex(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a.xml", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<urlset><url><loc>https://example.com/a</loc></url></urlset>`))
+	})
+	mux.HandleFunc("/b.xml", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<urlset><url><loc>https://example.com/b</loc></url></urlset>`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mux.HandleFunc("/sitemap_index.xml", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<?xml version="1.0"?>
+		<sitemapindex>
+			<sitemap><loc>` + srv.URL + `/a.xml</loc></sitemap>
+			<sitemap><loc>` + srv.URL + `/b.xml</loc></sitemap>
+		</sitemapindex>`))
+	})
+
+	urls, err := NewParser(srv.Client()).Discover(context.Background(), srv.URL+"/sitemap_index.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(urls) != 2 {
+		t.Fatalf("Discover() returned %d URLs, want 2", len(urls))
+	}
+}
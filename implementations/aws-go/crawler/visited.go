@@ -0,0 +1,76 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// visitedKey is the (crawlID, url) key for a VisitedStore item.
+type visitedKey struct {
+	PK string `dynamodbav:"pk"`
+	SK string `dynamodbav:"sk"`
+}
+
+func newVisitedKey(crawlID, url string) visitedKey {
+	return visitedKey{PK: "CRAWL#" + crawlID, SK: "VISITED#" + url}
+}
+
+// VisitedStore is a DynamoDB-backed set of URLs already seen by a crawl,
+// keyed by crawlID so a crawl that spans multiple Lambda invocations (driven
+// by a Step Functions state machine) resumes without re-fetching pages. Items
+// carry a "ttl" attribute so DynamoDB expires them once the crawl is long
+// over, instead of requiring an explicit cleanup job.
+type VisitedStore struct {
+	client *dynamodb.Client
+	table  string
+	ttl    time.Duration
+}
+
+// NewVisitedStore returns a VisitedStore backed by table, whose items expire
+// ttl after they are marked visited.
+func NewVisitedStore(client *dynamodb.Client, table string, ttl time.Duration) *VisitedStore {
+	return &VisitedStore{client: client, table: table, ttl: ttl}
+}
+
+// IsVisited reports whether url has already been marked visited for crawlID.
+func (s *VisitedStore) IsVisited(ctx context.Context, crawlID, url string) (bool, error) {
+	key, err := attributevalue.MarshalMap(newVisitedKey(crawlID, url))
+	if err != nil {
+		return false, fmt.Errorf("crawler: marshal visited key: %w", err)
+	}
+	resp, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(s.table),
+		Key:       key,
+	})
+	if err != nil {
+		return false, fmt.Errorf("crawler: get visited item: %w", err)
+	}
+	return resp.Item != nil, nil
+}
+
+// MarkVisited records url as visited for crawlID, expiring after the
+// store's TTL.
+func (s *VisitedStore) MarkVisited(ctx context.Context, crawlID, url string) error {
+	item, err := attributevalue.MarshalMap(newVisitedKey(crawlID, url))
+	if err != nil {
+		return fmt.Errorf("crawler: marshal visited key: %w", err)
+	}
+	item["ttl"] = &types.AttributeValueMemberN{
+		Value: strconv.FormatInt(time.Now().Add(s.ttl).Unix(), 10),
+	}
+	_, err = s.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(s.table),
+		Item:      item,
+	})
+	if err != nil {
+		return fmt.Errorf("crawler: put visited item: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package structured
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// extractJSONLD decodes every <script type="application/ld+json"> block on
+// the page, flattening @graph arrays so each schema.org entity — whether a
+// document's sole top-level object or one node of a @graph — ends up as its
+// own entry. A block that fails to parse contributes an Issue instead of an
+// entity.
+func extractJSONLD(doc *goquery.Document) ([]map[string]any, []Issue) {
+	var entities []map[string]any
+	var issues []Issue
+
+	doc.Find(`script[type="application/ld+json"]`).Each(func(_ int, s *goquery.Selection) {
+		raw := s.Text()
+		var v any
+		if err := json.Unmarshal([]byte(raw), &v); err != nil {
+			line, col := lineAndColumn(raw, syntaxErrorOffset(err))
+			issues = append(issues, Issue{
+				Source:  "json-ld",
+				Message: fmt.Sprintf("parse error: %v", err),
+				Line:    line,
+				Offset:  col,
+			})
+			return
+		}
+		entities = append(entities, flattenJSONLD(v)...)
+	})
+
+	return entities, issues
+}
+
+// flattenJSONLD expands a decoded JSON-LD value into a flat list of
+// entities, descending into "@graph" arrays and top-level arrays of nodes.
+func flattenJSONLD(v any) []map[string]any {
+	switch t := v.(type) {
+	case map[string]any:
+		if graph, ok := t["@graph"].([]any); ok {
+			var out []map[string]any
+			for _, g := range graph {
+				out = append(out, flattenJSONLD(g)...)
+			}
+			return out
+		}
+		return []map[string]any{t}
+	case []any:
+		var out []map[string]any
+		for _, e := range t {
+			out = append(out, flattenJSONLD(e)...)
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+// syntaxErrorOffset pulls the byte offset out of a JSON decoding error, if
+// the error carries one.
+func syntaxErrorOffset(err error) int64 {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return syntaxErr.Offset
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return typeErr.Offset
+	}
+	return 0
+}
+
+// lineAndColumn converts a byte offset into raw into a 1-based line number
+// and 0-based column, for reporting where a JSON-LD parse error occurred.
+func lineAndColumn(raw string, offset int64) (line, col int) {
+	line = 1
+	for i, r := range raw {
+		if int64(i) >= offset {
+			break
+		}
+		if r == '\n' {
+			line++
+			col = 0
+		} else {
+			col++
+		}
+	}
+	return line, col
+}
+
+// typeNames normalizes a decoded "@type" value, which schema.org allows to
+// be either a single string or an array of strings.
+func typeNames(t any) []string {
+	switch v := t.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var out []string
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+package structured
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// extractMicrodata walks every top-level [itemscope] element (one not
+// itself the value of an ancestor's itemprop) and reconstructs it into the
+// same map[string]any shape as a JSON-LD node, so both sources can share
+// the required-field rules in rules.go.
+func extractMicrodata(doc *goquery.Document) []map[string]any {
+	var items []map[string]any
+	doc.Find("[itemscope]").Each(func(_ int, s *goquery.Selection) {
+		if _, isNestedValue := s.Attr("itemprop"); isNestedValue {
+			return
+		}
+		items = append(items, buildMicrodataItem(s))
+	})
+	return items
+}
+
+// buildMicrodataItem reconstructs one itemscope element and its direct
+// itemprop descendants. An itemprop that is itself an itemscope becomes a
+// nested entity rather than a scalar value, recursing the same way.
+func buildMicrodataItem(scope *goquery.Selection) map[string]any {
+	item := make(map[string]any)
+	if itemtype, ok := scope.Attr("itemtype"); ok {
+		item["@type"] = classifyItemType(itemtype)
+	}
+	if itemID, ok := scope.Attr("itemid"); ok {
+		item["@id"] = itemID
+	}
+
+	scope.Find("[itemprop]").Each(func(_ int, prop *goquery.Selection) {
+		if !belongsTo(prop, scope) {
+			return
+		}
+		name, ok := prop.Attr("itemprop")
+		if !ok {
+			return
+		}
+		var value any
+		if _, isScope := prop.Attr("itemscope"); isScope {
+			value = buildMicrodataItem(prop)
+		} else {
+			value = microdataPropValue(prop)
+		}
+		addItemProp(item, name, value)
+	})
+
+	return item
+}
+
+// belongsTo reports whether prop's nearest itemscope ancestor is scope
+// itself, rather than some itemscope nested between them — that nested
+// scope owns the property, not scope.
+func belongsTo(prop, scope *goquery.Selection) bool {
+	nearest := prop.ParentsFiltered("[itemscope]").First()
+	return nearest.Length() > 0 && nearest.Get(0) == scope.Get(0)
+}
+
+// microdataPropValue reads the HTML microdata spec's value for an itemprop
+// element: the relevant attribute for elements with a natural URL or
+// machine-readable value, and trimmed text content otherwise.
+func microdataPropValue(s *goquery.Selection) string {
+	switch goquery.NodeName(s) {
+	case "meta":
+		v, _ := s.Attr("content")
+		return v
+	case "a", "area", "link":
+		v, _ := s.Attr("href")
+		return v
+	case "img", "audio", "video", "source", "track", "embed", "iframe":
+		v, _ := s.Attr("src")
+		return v
+	case "object":
+		v, _ := s.Attr("data")
+		return v
+	case "time":
+		if v, ok := s.Attr("datetime"); ok {
+			return v
+		}
+		return strings.TrimSpace(s.Text())
+	default:
+		return strings.TrimSpace(s.Text())
+	}
+}
+
+// addItemProp sets item[name] to value, upgrading to a slice if name was
+// already set — schema.org allows repeated itemprops (e.g. multiple
+// authors).
+func addItemProp(item map[string]any, name string, value any) {
+	existing, ok := item[name]
+	if !ok {
+		item[name] = value
+		return
+	}
+	if list, ok := existing.([]any); ok {
+		item[name] = append(list, value)
+		return
+	}
+	item[name] = []any{existing, value}
+}
+
+// classifyItemType reduces an itemtype URL (https://schema.org/Product) to
+// its bare type name (Product), matching the @type convention JSON-LD uses.
+func classifyItemType(itemtype string) string {
+	itemtype = strings.TrimSuffix(strings.TrimSpace(itemtype), "/")
+	if i := strings.LastIndexByte(itemtype, '/'); i >= 0 {
+		return itemtype[i+1:]
+	}
+	return itemtype
+}
@@ -0,0 +1,87 @@
+package structured
+
+import "fmt"
+
+// requiredFields lists the schema.org properties Extract expects to find on
+// common types. This is a pragmatic subset for SEO purposes, not the full
+// schema.org requirement set.
+var requiredFields = map[string][]string{
+	"Article":        {"headline", "datePublished", "image"},
+	"Product":        {"name", "offers"},
+	"BreadcrumbList": {"itemListElement"},
+	"FAQPage":        {"mainEntity"},
+}
+
+// requiredFieldIssues checks every entity against requiredFields and, for
+// Product, additionally verifies its offers carry a price.
+func requiredFieldIssues(source string, entities []map[string]any) []Issue {
+	var issues []Issue
+	for _, entity := range entities {
+		for _, typ := range typeNames(entity["@type"]) {
+			fields, ok := requiredFields[typ]
+			if !ok {
+				continue
+			}
+			for _, field := range fields {
+				if _, present := entity[field]; !present {
+					issues = append(issues, Issue{
+						Source:  source,
+						Type:    typ,
+						Message: fmt.Sprintf("%s is missing required field %q", typ, field),
+					})
+				}
+			}
+			if typ == "Product" {
+				if offers, ok := entity["offers"]; ok && !offersHavePrice(offers) {
+					issues = append(issues, Issue{
+						Source:  source,
+						Type:    typ,
+						Message: "Product offers is missing price",
+					})
+				}
+			}
+		}
+	}
+	return issues
+}
+
+// offersHavePrice reports whether a Product's offers value (a single Offer
+// object or an array of them) declares a price.
+func offersHavePrice(offers any) bool {
+	switch o := offers.(type) {
+	case map[string]any:
+		_, ok := o["price"]
+		return ok
+	case []any:
+		for _, e := range o {
+			if m, ok := e.(map[string]any); ok {
+				if _, ok := m["price"]; ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// duplicateIDIssues flags entities that share an "@id", which breaks
+// JSON-LD's ability to cross-reference nodes by reference.
+func duplicateIDIssues(entities []map[string]any) []Issue {
+	seen := make(map[string]bool)
+	var issues []Issue
+	for _, entity := range entities {
+		id, ok := entity["@id"].(string)
+		if !ok || id == "" {
+			continue
+		}
+		if seen[id] {
+			issues = append(issues, Issue{
+				Source:  "json-ld",
+				Message: fmt.Sprintf("duplicate @id %q", id),
+			})
+			continue
+		}
+		seen[id] = true
+	}
+	return issues
+}
@@ -0,0 +1,111 @@
+// Package structured extracts the structured data a page exposes to search
+// engines and social platforms: JSON-LD, microdata, and OpenGraph/Twitter
+// meta tags. It also flags common authoring mistakes — missing required
+// fields for well-known schema.org types, duplicate @ids, and malformed
+// JSON-LD — as Issues a crawl can surface without a human reading markup.
+package structured
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/bwatson/seo-solver/aws-go/telemetry"
+)
+
+// Result holds everything Extract found on a page. JSONLD and Microdata are
+// both represented as map[string]any in schema.org's own shape (an "@type"
+// key plus property keys), so downstream code can treat either source the
+// same way once extracted.
+type Result struct {
+	JSONLD    []map[string]any
+	Microdata []map[string]any
+	OpenGraph map[string]string
+	Twitter   map[string]string
+}
+
+// Issue is a problem found in a page's structured data.
+type Issue struct {
+	Source  string // "json-ld" or "microdata"
+	Type    string // schema.org @type this issue concerns, if any
+	Message string
+	Line    int // 1-based line in the source script block; json-ld parse errors only
+	Offset  int // 0-based column on Line; json-ld parse errors only
+}
+
+// Extract walks doc and returns its structured data plus any Issues found
+// against the required-field rules in rules.go. logger may be nil; when
+// set, a parse error in a JSON-LD block is logged tagged with the crawlID
+// carried on ctx (see telemetry.WithCrawlID), correlating it back to the
+// crawl invocation that fetched the page.
+func Extract(ctx context.Context, doc *goquery.Document, logger *telemetry.Logger) (Result, []Issue, error) {
+	jsonLD, parseIssues := extractJSONLD(doc)
+	microdata := extractMicrodata(doc)
+
+	result := Result{
+		JSONLD:    jsonLD,
+		Microdata: microdata,
+		OpenGraph: extractMetaPrefixed(doc, "property", "og:"),
+		Twitter:   extractMetaPrefixed(doc, "name", "twitter:"),
+	}
+
+	for _, issue := range parseIssues {
+		logger.Error(ctx, "structured data parse error", nil, telemetry.Fields{
+			"source": issue.Source,
+			"line":   issue.Line,
+			"offset": issue.Offset,
+		})
+	}
+
+	var issues []Issue
+	issues = append(issues, parseIssues...)
+	issues = append(issues, requiredFieldIssues("json-ld", jsonLD)...)
+	issues = append(issues, requiredFieldIssues("microdata", microdata)...)
+	issues = append(issues, duplicateIDIssues(jsonLD)...)
+
+	return result, issues, nil
+}
+
+// extractMetaPrefixed collects <meta attr="prefix*" content="..."> tags
+// into a map keyed by the full attr value, covering both OpenGraph
+// (property="og:...") and Twitter Card (name="twitter:...") conventions.
+func extractMetaPrefixed(doc *goquery.Document, attr, prefix string) map[string]string {
+	out := make(map[string]string)
+	selector := fmt.Sprintf(`meta[%s^="%s"]`, attr, prefix)
+	doc.Find(selector).Each(func(_ int, s *goquery.Selection) {
+		key, ok := s.Attr(attr)
+		if !ok {
+			return
+		}
+		content, _ := s.Attr("content")
+		out[key] = content
+	})
+	return out
+}
+
+// Summarize reduces a Result and its Issues to the flat, DynamoDB-friendly
+// fields stored on a PageAudit record: the distinct schema.org types found
+// across JSON-LD and microdata, and the human-readable issue messages. This
+// keeps the audit item queryable (e.g. "pages with a Product type and a
+// missing-price issue") without storing the full structured-data payload.
+func Summarize(result Result, issues []Issue) (types []string, issueMessages []string) {
+	seen := make(map[string]bool)
+	addTypes := func(entities []map[string]any) {
+		for _, ent := range entities {
+			for _, t := range typeNames(ent["@type"]) {
+				if !seen[t] {
+					seen[t] = true
+					types = append(types, t)
+				}
+			}
+		}
+	}
+	addTypes(result.JSONLD)
+	addTypes(result.Microdata)
+
+	for _, issue := range issues {
+		issueMessages = append(issueMessages, issue.Message)
+	}
+	return types, issueMessages
+}
@@ -0,0 +1,167 @@
+package structured
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func mustParse(t *testing.T, html string) *goquery.Document {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestExtractJSONLDFlattensGraph(t *testing.T) {
+	doc := mustParse(t, `<html><head><script type="application/ld+json">
+	{"@context":"https://schema.org","@graph":[
+		{"@type":"Article","@id":"a1","headline":"H","datePublished":"2026-01-01","image":"i.jpg"},
+		{"@type":"Product","@id":"p1","name":"Widget"}
+	]}
+	</script></head><body></body></html>`)
+
+	result, issues, err := Extract(context.Background(), doc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.JSONLD) != 2 {
+		t.Fatalf("got %d JSON-LD entities, want 2", len(result.JSONLD))
+	}
+
+	foundMissingOffers := false
+	for _, issue := range issues {
+		if issue.Type == "Product" && strings.Contains(issue.Message, "offers") {
+			foundMissingOffers = true
+		}
+	}
+	if !foundMissingOffers {
+		t.Errorf("expected a missing-offers issue for the Product node, got %+v", issues)
+	}
+}
+
+func TestExtractJSONLDParseError(t *testing.T) {
+	doc := mustParse(t, `<html><head><script type="application/ld+json">{not valid json</script></head></html>`)
+
+	_, issues, err := Extract(context.Background(), doc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 1 || issues[0].Source != "json-ld" {
+		t.Fatalf("issues = %+v, want one json-ld parse issue", issues)
+	}
+}
+
+func TestExtractProductMissingPrice(t *testing.T) {
+	doc := mustParse(t, `<html><head><script type="application/ld+json">
+	{"@type":"Product","name":"Widget","offers":{"@type":"Offer","priceCurrency":"USD"}}
+	</script></head></html>`)
+
+	_, issues, err := Extract(context.Background(), doc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotPriceIssue bool
+	for _, issue := range issues {
+		if strings.Contains(issue.Message, "missing price") {
+			gotPriceIssue = true
+		}
+	}
+	if !gotPriceIssue {
+		t.Errorf("expected a missing-price issue, got %+v", issues)
+	}
+}
+
+func TestExtractMicrodata(t *testing.T) {
+	doc := mustParse(t, `<html><body>
+	<div itemscope itemtype="https://schema.org/Product">
+		<span itemprop="name">Widget</span>
+		<div itemprop="offers" itemscope itemtype="https://schema.org/Offer">
+			<span itemprop="price">19.99</span>
+		</div>
+	</div>
+	</body></html>`)
+
+	result, _, err := Extract(context.Background(), doc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Microdata) != 1 {
+		t.Fatalf("got %d microdata items, want 1", len(result.Microdata))
+	}
+	item := result.Microdata[0]
+	if item["@type"] != "Product" {
+		t.Errorf("@type = %v, want Product", item["@type"])
+	}
+	if item["name"] != "Widget" {
+		t.Errorf("name = %v, want Widget", item["name"])
+	}
+	offers, ok := item["offers"].(map[string]any)
+	if !ok {
+		t.Fatalf("offers = %#v, want nested map", item["offers"])
+	}
+	if offers["price"] != "19.99" {
+		t.Errorf("offers.price = %v, want 19.99", offers["price"])
+	}
+}
+
+func TestExtractOpenGraphAndTwitter(t *testing.T) {
+	doc := mustParse(t, `<html><head>
+	<meta property="og:title" content="Title">
+	<meta property="og:type" content="article">
+	<meta name="twitter:card" content="summary">
+	</head></html>`)
+
+	result, _, err := Extract(context.Background(), doc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.OpenGraph["og:title"] != "Title" {
+		t.Errorf("og:title = %q, want Title", result.OpenGraph["og:title"])
+	}
+	if result.Twitter["twitter:card"] != "summary" {
+		t.Errorf("twitter:card = %q, want summary", result.Twitter["twitter:card"])
+	}
+}
+
+func TestDuplicateID(t *testing.T) {
+	doc := mustParse(t, `<html><head>
+	<script type="application/ld+json">{"@type":"Article","@id":"x","headline":"H","datePublished":"d","image":"i"}</script>
+	<script type="application/ld+json">{"@type":"Article","@id":"x","headline":"H2","datePublished":"d","image":"i"}</script>
+	</head></html>`)
+
+	_, issues, err := Extract(context.Background(), doc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dupFound bool
+	for _, issue := range issues {
+		if strings.Contains(issue.Message, "duplicate @id") {
+			dupFound = true
+		}
+	}
+	if !dupFound {
+		t.Errorf("expected a duplicate @id issue, got %+v", issues)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	doc := mustParse(t, `<html><head><script type="application/ld+json">
+	{"@type":"Product","name":"Widget"}
+	</script></head></html>`)
+	result, issues, err := Extract(context.Background(), doc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	types, messages := Summarize(result, issues)
+	if len(types) != 1 || types[0] != "Product" {
+		t.Errorf("types = %v, want [Product]", types)
+	}
+	if len(messages) != len(issues) {
+		t.Errorf("got %d messages, want %d", len(messages), len(issues))
+	}
+}
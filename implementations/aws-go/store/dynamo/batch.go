@@ -0,0 +1,109 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// maxBatchWriteItems is the DynamoDB BatchWriteItem limit on requests per call.
+const maxBatchWriteItems = 25
+
+// maxUnprocessedRetries bounds how many times Flush retries UnprocessedItems
+// before giving up. DynamoDB returns unprocessed items under throttling, so
+// retries are backed off with simple exponential delay.
+const maxUnprocessedRetries = 5
+
+// entry is a pending (site, url, timestamp, item) write, staged until Flush.
+type entry struct {
+	site, url string
+	ts        time.Time
+	write     types.WriteRequest
+}
+
+// BatchWriter buffers Put calls for T and flushes them via BatchWriteItem in
+// batches of up to 25, retrying any UnprocessedItems DynamoDB hands back.
+// It is not safe for concurrent use.
+type BatchWriter[T any] struct {
+	table   *Table
+	pending []entry
+}
+
+// NewBatchWriter returns a BatchWriter for T backed by table.
+func NewBatchWriter[T any](table *Table) *BatchWriter[T] {
+	return &BatchWriter[T]{table: table}
+}
+
+// Put stages item for the next Flush. It does not make any network calls.
+func (w *BatchWriter[T]) Put(site, url string, ts time.Time, item T) error {
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return fmt.Errorf("dynamo: marshal item: %w", err)
+	}
+	key, err := keyAttributeValues(site, url, ts)
+	if err != nil {
+		return fmt.Errorf("dynamo: marshal key: %w", err)
+	}
+	for k, v := range key {
+		av[k] = v
+	}
+	w.pending = append(w.pending, entry{
+		site: site, url: url, ts: ts,
+		write: types.WriteRequest{PutRequest: &types.PutRequest{Item: av}},
+	})
+	return nil
+}
+
+// Flush writes every staged entry to DynamoDB in batches of 25, retrying
+// UnprocessedItems with exponential backoff. It returns the first error
+// encountered; successfully written batches are not rolled back. On success
+// the writer's pending entries are cleared so it can be reused.
+func (w *BatchWriter[T]) Flush(ctx context.Context) error {
+	for start := 0; start < len(w.pending); start += maxBatchWriteItems {
+		end := start + maxBatchWriteItems
+		if end > len(w.pending) {
+			end = len(w.pending)
+		}
+		writes := make([]types.WriteRequest, end-start)
+		for i, e := range w.pending[start:end] {
+			writes[i] = e.write
+		}
+		if err := w.flushBatch(ctx, writes); err != nil {
+			return err
+		}
+	}
+	w.pending = nil
+	return nil
+}
+
+func (w *BatchWriter[T]) flushBatch(ctx context.Context, writes []types.WriteRequest) error {
+	reqItems := map[string][]types.WriteRequest{w.table.Name: writes}
+
+	backoff := 50 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		resp, err := w.table.Client.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{
+			RequestItems: reqItems,
+		})
+		if err != nil {
+			return fmt.Errorf("dynamo: batch write item: %w", err)
+		}
+		if len(resp.UnprocessedItems) == 0 {
+			return nil
+		}
+		if attempt >= maxUnprocessedRetries {
+			return fmt.Errorf("dynamo: %d items still unprocessed after %d retries",
+				len(resp.UnprocessedItems[w.table.Name]), maxUnprocessedRetries)
+		}
+		reqItems = resp.UnprocessedItems
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,50 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBatchWriterFlushRetriesUnprocessedItems(t *testing.T) {
+	table := newFakeTable(t, map[string][]cannedResponse{
+		"DynamoDB_20120810.BatchWriteItem": {
+			{status: 200, body: `{"UnprocessedItems":{"test-table":[{"PutRequest":{"Item":{"pk":{"S":"SITE#example.com"},"sk":{"S":"URL#/a#TS#x"}}}}]}}`},
+			{status: 200, body: `{"UnprocessedItems":{}}`},
+		},
+	})
+
+	w := NewBatchWriter[KeywordScore](table)
+	if err := w.Put("example.com", "/a", time.Now(), KeywordScore{Keyword: "seo", Score: 0.9, Occurs: 3}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if err := w.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if len(w.pending) != 0 {
+		t.Errorf("pending = %d entries after a successful Flush, want 0", len(w.pending))
+	}
+}
+
+func TestBatchWriterFlushGivesUpAfterMaxRetries(t *testing.T) {
+	unprocessed := cannedResponse{status: 200, body: `{"UnprocessedItems":{"test-table":[{"PutRequest":{"Item":{"pk":{"S":"SITE#example.com"},"sk":{"S":"URL#/a#TS#x"}}}}]}}`}
+	responses := make([]cannedResponse, maxUnprocessedRetries+1)
+	for i := range responses {
+		responses[i] = unprocessed
+	}
+	table := newFakeTable(t, map[string][]cannedResponse{
+		"DynamoDB_20120810.BatchWriteItem": responses,
+	})
+
+	ctx := context.Background()
+
+	w := NewBatchWriter[KeywordScore](table)
+	if err := w.Put("example.com", "/a", time.Now(), KeywordScore{Keyword: "seo"}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if err := w.Flush(ctx); err == nil {
+		t.Error("Flush() with permanently unprocessed items = nil error, want non-nil")
+	}
+}
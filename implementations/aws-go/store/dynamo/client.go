@@ -0,0 +1,31 @@
+// Package dynamo provides a typed repository layer over Amazon DynamoDB for
+// the crawl results, keyword scores, and page audits produced by the
+// seo-solver Lambda handlers. It wraps attributevalue marshaling so callers
+// work with plain Go structs tagged `dynamodbav:"..."` instead of hand
+// building map[string]types.AttributeValue.
+package dynamo
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"github.com/bwatson/seo-solver/aws-go/telemetry"
+)
+
+// Table binds a dynamodb.Client to a single table name. All records stored
+// through a Table share the single-table key schema described in key.go:
+// partition key "pk" = "SITE#<site>", sort key "sk" = "URL#<url>#TS#<rfc3339nano>".
+//
+// Logger is optional; when set, every Repository.Put logs its latency and
+// the crawlID carried on the request context (see telemetry.WithCrawlID) so
+// a DynamoDB item and the log lines around it can be correlated back to one
+// crawl invocation. Repository.Delete and BatchWriter.Flush do not log.
+type Table struct {
+	Client *dynamodb.Client
+	Name   string
+	Logger *telemetry.Logger
+}
+
+// NewTable returns a Table bound to name using client for all requests.
+func NewTable(client *dynamodb.Client, name string) *Table {
+	return &Table{Client: client, Name: name}
+}
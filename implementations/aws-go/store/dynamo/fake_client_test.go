@@ -0,0 +1,56 @@
+package dynamo
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// cannedResponse is one scripted HTTP response for a single DynamoDB
+// operation, identified by its X-Amz-Target header (e.g.
+// "DynamoDB_20120810.Query").
+type cannedResponse struct {
+	status int
+	body   string
+}
+
+// fakeTransport serves a queue of cannedResponses per operation, in call
+// order, so a test can script a retry-then-succeed or paginated sequence
+// without a real DynamoDB endpoint.
+type fakeTransport struct {
+	t         *testing.T
+	responses map[string][]cannedResponse
+}
+
+func (f *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target := req.Header.Get("X-Amz-Target")
+	queue := f.responses[target]
+	if len(queue) == 0 {
+		f.t.Fatalf("fakeTransport: no more canned responses for %s", target)
+	}
+	resp := queue[0]
+	f.responses[target] = queue[1:]
+	return &http.Response{
+		StatusCode: resp.status,
+		Status:     http.StatusText(resp.status),
+		Body:       io.NopCloser(bytes.NewBufferString(resp.body)),
+		Header:     http.Header{"Content-Type": []string{"application/x-amz-json-1.0"}},
+	}, nil
+}
+
+// newFakeTable returns a Table whose Client sends requests to responses
+// instead of a real DynamoDB endpoint, keyed by X-Amz-Target.
+func newFakeTable(t *testing.T, responses map[string][]cannedResponse) *Table {
+	t.Helper()
+	cfg := aws.Config{
+		Region:      "us-east-1",
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  &http.Client{Transport: &fakeTransport{t: t, responses: responses}},
+	}
+	client := dynamodb.NewFromConfig(cfg)
+	return NewTable(client, "test-table")
+}
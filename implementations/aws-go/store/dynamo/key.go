@@ -0,0 +1,42 @@
+package dynamo
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// itemKey is the composite (site, url, timestamp) primary key shared by
+// every record type this package stores. Writers marshal it separately and
+// merge its attributes into the item's own map, which keeps key derivation
+// in one place.
+type itemKey struct {
+	PK string `dynamodbav:"pk"`
+	SK string `dynamodbav:"sk"`
+}
+
+// timestampLayout formats a time with a fixed-width nanosecond fraction, so
+// that lexicographic sort key ordering matches chronological ordering.
+// time.RFC3339Nano is unsuitable here: it omits the fractional-second field
+// entirely when it is zero, which makes an on-the-second timestamp (no
+// trailing ".0...") sort *after* a later, sub-second one within the same
+// second for the same site and URL.
+const timestampLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// newItemKey builds the partition/sort key pair for a (site, url, timestamp)
+// triple. ts is normalized to UTC and formatted with fixed-width nanosecond
+// precision so that lexicographic sort key ordering matches chronological
+// ordering.
+func newItemKey(site, url string, ts time.Time) itemKey {
+	return itemKey{
+		PK: "SITE#" + site,
+		SK: "URL#" + url + "#TS#" + ts.UTC().Format(timestampLayout),
+	}
+}
+
+// keyAttributeValues marshals the key for use in PutItem, BatchWriteItem,
+// GetItem, and DeleteItem requests.
+func keyAttributeValues(site, url string, ts time.Time) (map[string]types.AttributeValue, error) {
+	return attributevalue.MarshalMap(newItemKey(site, url, ts))
+}
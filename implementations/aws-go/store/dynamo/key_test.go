@@ -0,0 +1,50 @@
+package dynamo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewItemKey(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("PST", -8*60*60))
+
+	got := newItemKey("example.com", "/blog/post", ts)
+
+	wantPK := "SITE#example.com"
+	if got.PK != wantPK {
+		t.Errorf("PK = %q, want %q", got.PK, wantPK)
+	}
+
+	wantSK := "URL#/blog/post#TS#2026-01-02T11:04:05.000000000Z"
+	if got.SK != wantSK {
+		t.Errorf("SK = %q, want %q", got.SK, wantSK)
+	}
+}
+
+func TestNewItemKeyOrdersChronologically(t *testing.T) {
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	earlier := newItemKey("example.com", "/p", base)
+	later := newItemKey("example.com", "/p", base.Add(time.Second))
+
+	if !(earlier.SK < later.SK) {
+		t.Errorf("expected earlier sort key %q < later sort key %q", earlier.SK, later.SK)
+	}
+}
+
+// TestNewItemKeyOrdersZeroNanosBeforeSameSecondFraction guards against
+// time.RFC3339Nano, which drops the fractional-second field entirely when it
+// is zero: an on-the-second timestamp (e.g. the day-truncated ones
+// audit.SaveSiteScore writes) must still sort before a later sub-second
+// timestamp in the same second, even though its formatted string has no
+// ".<digits>" suffix to compare against.
+func TestNewItemKeyOrdersZeroNanosBeforeSameSecondFraction(t *testing.T) {
+	onTheSecond := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	halfSecondLater := onTheSecond.Add(500 * time.Millisecond)
+
+	earlier := newItemKey("example.com", "/p", onTheSecond)
+	later := newItemKey("example.com", "/p", halfSecondLater)
+
+	if !(earlier.SK < later.SK) {
+		t.Errorf("expected zero-ns sort key %q < same-second sort key %q", earlier.SK, later.SK)
+	}
+}
@@ -0,0 +1,61 @@
+package dynamo
+
+import "time"
+
+// CrawlResult is the per-page outcome of a single crawl fetch: status code,
+// basic on-page signals, and crawl bookkeeping. One record is written per
+// (site, url, timestamp) the crawler visits.
+type CrawlResult struct {
+	Site            string         `dynamodbav:"site"`
+	URL             string         `dynamodbav:"url"`
+	Timestamp       time.Time      `dynamodbav:"timestamp"`
+	StatusCode      int            `dynamodbav:"statusCode"`
+	Title           string         `dynamodbav:"title"`
+	MetaDescription string         `dynamodbav:"metaDescription"`
+	Canonical       string         `dynamodbav:"canonical,omitempty"`
+	HeadingCounts   map[string]int `dynamodbav:"headingCounts,omitempty"`
+	WordCount       int            `dynamodbav:"wordCount"`
+	OutboundLinks   []string       `dynamodbav:"outboundLinks,omitempty"`
+}
+
+// KeywordScore records a single keyword's relevance score for a page at the
+// time it was crawled.
+type KeywordScore struct {
+	Site      string    `dynamodbav:"site"`
+	URL       string    `dynamodbav:"url"`
+	Timestamp time.Time `dynamodbav:"timestamp"`
+	Keyword   string    `dynamodbav:"keyword"`
+	Score     float64   `dynamodbav:"score"`
+	Occurs    int       `dynamodbav:"occurs"`
+}
+
+// PageAudit is the aggregate SEO audit for a page as of a given crawl.
+// StructuredTypes and StructuredIssues are a flattened summary of the page's
+// JSON-LD/microdata (see seo/structured), kept here rather than as a nested
+// blob so queries like "pages with a Product type and a missing-price
+// issue" stay simple DynamoDB filter expressions.
+type PageAudit struct {
+	Site             string    `dynamodbav:"site"`
+	URL              string    `dynamodbav:"url"`
+	Timestamp        time.Time `dynamodbav:"timestamp"`
+	Score            int       `dynamodbav:"score"`
+	Findings         []string  `dynamodbav:"findings,omitempty"`
+	StructuredTypes  []string  `dynamodbav:"structuredTypes,omitempty"`
+	StructuredIssues []string  `dynamodbav:"structuredIssues,omitempty"`
+}
+
+// SiteScore is a site-level rollup of page scores for one day, stored via
+// Repository[SiteScore].Put(ctx, site, RollupURLKey, date, score) so its key
+// schema matches every other record type: the "url" slot holds the
+// RollupURLKey sentinel instead of a real page URL.
+type SiteScore struct {
+	Site      string    `dynamodbav:"site"`
+	Date      string    `dynamodbav:"date"`
+	Timestamp time.Time `dynamodbav:"timestamp"`
+	Score     int       `dynamodbav:"score"`
+	PageCount int       `dynamodbav:"pageCount"`
+}
+
+// RollupURLKey is the sentinel "url" under which SiteScore records are
+// stored, since a site-level rollup has no single page URL of its own.
+const RollupURLKey = "__site_rollup__"
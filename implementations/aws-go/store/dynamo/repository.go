@@ -0,0 +1,157 @@
+package dynamo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/bwatson/seo-solver/aws-go/telemetry"
+)
+
+// Repository is a typed CRUD layer over a Table for a single record type T.
+// T should be a plain struct tagged with `dynamodbav:"..."`; Repository
+// merges in the (site, url, timestamp) key attributes on write and strips
+// nothing special on read, so T may also declare its own "pk"/"sk" fields
+// if a caller wants them visible.
+type Repository[T any] struct {
+	table *Table
+}
+
+// NewRepository returns a Repository for T backed by table.
+func NewRepository[T any](table *Table) *Repository[T] {
+	return &Repository[T]{table: table}
+}
+
+// Put marshals item and writes it under the key (site, url, ts), overwriting
+// any existing record at that key.
+func (r *Repository[T]) Put(ctx context.Context, site, url string, ts time.Time, item T) error {
+	start := time.Now()
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return fmt.Errorf("dynamo: marshal item: %w", err)
+	}
+	key, err := keyAttributeValues(site, url, ts)
+	if err != nil {
+		return fmt.Errorf("dynamo: marshal key: %w", err)
+	}
+	for k, v := range key {
+		av[k] = v
+	}
+	_, err = r.table.Client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(r.table.Name),
+		Item:      av,
+	})
+	r.table.Logger.Info(ctx, "dynamo put item", telemetry.Fields{
+		"table":     r.table.Name,
+		"url":       url,
+		"latencyMs": time.Since(start).Milliseconds(),
+		"ok":        err == nil,
+	})
+	if err != nil {
+		return fmt.Errorf("dynamo: put item: %w", err)
+	}
+	return nil
+}
+
+// Get fetches the record at (site, url, ts). It returns (zero, false, nil)
+// when no item exists at that key.
+func (r *Repository[T]) Get(ctx context.Context, site, url string, ts time.Time) (T, bool, error) {
+	var out T
+	key, err := keyAttributeValues(site, url, ts)
+	if err != nil {
+		return out, false, fmt.Errorf("dynamo: marshal key: %w", err)
+	}
+	resp, err := r.table.Client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.table.Name),
+		Key:       key,
+	})
+	if err != nil {
+		return out, false, fmt.Errorf("dynamo: get item: %w", err)
+	}
+	if resp.Item == nil {
+		return out, false, nil
+	}
+	if err := attributevalue.UnmarshalMap(resp.Item, &out); err != nil {
+		return out, false, fmt.Errorf("dynamo: unmarshal item: %w", err)
+	}
+	return out, true, nil
+}
+
+// Delete removes the record at (site, url, ts), if any.
+func (r *Repository[T]) Delete(ctx context.Context, site, url string, ts time.Time) error {
+	key, err := keyAttributeValues(site, url, ts)
+	if err != nil {
+		return fmt.Errorf("dynamo: marshal key: %w", err)
+	}
+	_, err = r.table.Client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(r.table.Name),
+		Key:       key,
+	})
+	if err != nil {
+		return fmt.Errorf("dynamo: delete item: %w", err)
+	}
+	return nil
+}
+
+// Query returns every record for site, in sort-key order, across as many
+// pages as DynamoDB requires. skPrefix, if non-empty, is appended to a
+// begins_with condition on the sort key (e.g. "URL#"+url+"#" to scope to
+// one URL's history).
+func (r *Repository[T]) Query(ctx context.Context, site, skPrefix string) ([]T, error) {
+	keyCond := "pk = :pk"
+	exprValues := map[string]types.AttributeValue{
+		":pk": &types.AttributeValueMemberS{Value: "SITE#" + site},
+	}
+	if skPrefix != "" {
+		keyCond += " AND begins_with(sk, :skPrefix)"
+		exprValues[":skPrefix"] = &types.AttributeValueMemberS{Value: skPrefix}
+	}
+
+	paginator := dynamodb.NewQueryPaginator(r.table.Client, &dynamodb.QueryInput{
+		TableName:                 aws.String(r.table.Name),
+		KeyConditionExpression:    aws.String(keyCond),
+		ExpressionAttributeValues: exprValues,
+	})
+
+	var out []T
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("dynamo: query page: %w", err)
+		}
+		var items []T
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, fmt.Errorf("dynamo: unmarshal query page: %w", err)
+		}
+		out = append(out, items...)
+	}
+	return out, nil
+}
+
+// Scan returns every record in the table across as many pages as DynamoDB
+// requires. It is intended for maintenance and backfill jobs, not request
+// paths; prefer Query wherever the partition key is known.
+func (r *Repository[T]) Scan(ctx context.Context) ([]T, error) {
+	paginator := dynamodb.NewScanPaginator(r.table.Client, &dynamodb.ScanInput{
+		TableName: aws.String(r.table.Name),
+	})
+
+	var out []T
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("dynamo: scan page: %w", err)
+		}
+		var items []T
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, fmt.Errorf("dynamo: unmarshal scan page: %w", err)
+		}
+		out = append(out, items...)
+	}
+	return out, nil
+}
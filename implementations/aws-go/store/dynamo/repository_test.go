@@ -0,0 +1,47 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRepositoryQueryPaginates(t *testing.T) {
+	table := newFakeTable(t, map[string][]cannedResponse{
+		"DynamoDB_20120810.Query": {
+			{status: 200, body: `{"Items":[{"site":{"S":"example.com"},"url":{"S":"/a"},"keyword":{"S":"seo"}}],"LastEvaluatedKey":{"pk":{"S":"SITE#example.com"},"sk":{"S":"URL#/a#TS#x"}}}`},
+			{status: 200, body: `{"Items":[{"site":{"S":"example.com"},"url":{"S":"/b"},"keyword":{"S":"audit"}}]}`},
+		},
+	})
+
+	repo := NewRepository[KeywordScore](table)
+	got, err := repo.Query(context.Background(), "example.com", "")
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("Query returned %d items across pages, want 2", len(got))
+	}
+	if got[0].Keyword != "seo" || got[1].Keyword != "audit" {
+		t.Errorf("Query items = %+v, want keywords [seo audit] in page order", got)
+	}
+}
+
+func TestRepositoryScanPaginates(t *testing.T) {
+	table := newFakeTable(t, map[string][]cannedResponse{
+		"DynamoDB_20120810.Scan": {
+			{status: 200, body: `{"Items":[{"site":{"S":"example.com"},"url":{"S":"/a"},"keyword":{"S":"seo"}}],"LastEvaluatedKey":{"pk":{"S":"SITE#example.com"},"sk":{"S":"URL#/a#TS#x"}}}`},
+			{status: 200, body: `{"Items":[]}`},
+		},
+	})
+
+	repo := NewRepository[KeywordScore](table)
+	got, err := repo.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+
+	if len(got) != 1 || got[0].Keyword != "seo" {
+		t.Errorf("Scan returned %+v, want a single seo record", got)
+	}
+}
@@ -0,0 +1,65 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+// CloudWatchWriter optionally mirrors log lines directly into a CloudWatch
+// Logs stream via PutLogEvents, for callers that want log delivery
+// independent of whatever is tailing the Lambda's stdout.
+type CloudWatchWriter struct {
+	client    *cloudwatchlogs.Client
+	logGroup  string
+	logStream string
+
+	mu     sync.Mutex
+	events []types.InputLogEvent
+}
+
+// NewCloudWatchWriter returns a CloudWatchWriter that flushes to logStream
+// within logGroup.
+func NewCloudWatchWriter(client *cloudwatchlogs.Client, logGroup, logStream string) *CloudWatchWriter {
+	return &CloudWatchWriter{client: client, logGroup: logGroup, logStream: logStream}
+}
+
+// Enqueue buffers message for the next Flush.
+func (w *CloudWatchWriter) Enqueue(message string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.events = append(w.events, types.InputLogEvent{
+		Message:   aws.String(message),
+		Timestamp: aws.Int64(time.Now().UnixMilli()),
+	})
+}
+
+// Flush writes every buffered event to CloudWatch Logs in a single
+// PutLogEvents call. CloudWatch Logs requires the events in that call to
+// already be in chronological order, which Enqueue's append-only buffer
+// guarantees.
+func (w *CloudWatchWriter) Flush(ctx context.Context) error {
+	w.mu.Lock()
+	events := w.events
+	w.events = nil
+	w.mu.Unlock()
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	_, err := w.client.PutLogEvents(ctx, &cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String(w.logGroup),
+		LogStreamName: aws.String(w.logStream),
+		LogEvents:     events,
+	})
+	if err != nil {
+		return fmt.Errorf("telemetry: put log events: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package telemetry
+
+import "context"
+
+// crawlIDKey is an unexported type so WithCrawlID's value can't collide
+// with context keys set by other packages.
+type crawlIDKey struct{}
+
+// WithCrawlID returns a copy of ctx carrying crawlID, so every package a
+// single crawl invocation passes ctx through — the crawler, the structured
+// data extractor, and the DynamoDB writer — can log and tag items under the
+// same identifier without threading it as a separate parameter everywhere.
+func WithCrawlID(ctx context.Context, crawlID string) context.Context {
+	return context.WithValue(ctx, crawlIDKey{}, crawlID)
+}
+
+// CrawlIDFromContext returns the crawlID set by WithCrawlID, if any.
+func CrawlIDFromContext(ctx context.Context) (string, bool) {
+	crawlID, ok := ctx.Value(crawlIDKey{}).(string)
+	return crawlID, ok
+}
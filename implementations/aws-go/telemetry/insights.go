@@ -0,0 +1,14 @@
+package telemetry
+
+import "fmt"
+
+// LogsInsightsQuery returns a CloudWatch Logs Insights query string scoped
+// to crawlID, ready to hand back in a Lambda response so an operator can
+// paste it straight into the console. It mirrors the per-rule latency
+// breakdown this package's "rule" and "latencyMs" log fields are shaped for.
+func LogsInsightsQuery(crawlID string) string {
+	return fmt.Sprintf(
+		`filter crawlID = "%s" | stats avg(latencyMs) as avgLatencyMs, count() as events by rule`,
+		crawlID,
+	)
+}
@@ -0,0 +1,98 @@
+// Package telemetry gives every Lambda handler in this module a shared way
+// to emit structured logs and correlate them, plus the DynamoDB items they
+// write, back to a single crawl invocation. Log lines are single-line JSON
+// on stdout so CloudWatch Logs Insights can query them directly (e.g.
+// `stats avg(latencyMs) by rule`); a CloudWatchWriter is available for
+// callers that also want direct PutLogEvents delivery.
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Fields are the extra, call-site-specific keys merged into a log line:
+// things like "url", "rule", "depth", "latencyMs", and "status".
+type Fields map[string]any
+
+// Logger writes single-line JSON log entries to out, and optionally mirrors
+// them to a CloudWatchWriter. The zero value is not usable; use New. A nil
+// *Logger is safe to call methods on and simply discards every entry, so
+// callers that haven't wired up logging yet don't need nil checks.
+type Logger struct {
+	mu  sync.Mutex
+	out io.Writer
+	cw  *CloudWatchWriter
+}
+
+// New returns a Logger that writes to out. A nil out defaults to os.Stdout.
+func New(out io.Writer) *Logger {
+	if out == nil {
+		out = os.Stdout
+	}
+	return &Logger{out: out}
+}
+
+// WithCloudWatch attaches cw so every logged entry is also enqueued for
+// direct CloudWatch Logs delivery, and returns l for chaining.
+func (l *Logger) WithCloudWatch(cw *CloudWatchWriter) *Logger {
+	if l == nil {
+		return nil
+	}
+	l.cw = cw
+	return l
+}
+
+// Log writes one JSON line containing ts, level, msg, the crawlID carried on
+// ctx (if any, via WithCrawlID), and fields.
+func (l *Logger) Log(ctx context.Context, level, msg string, fields Fields) {
+	if l == nil {
+		return
+	}
+
+	entry := Fields{
+		"ts":    time.Now().UTC().Format(time.RFC3339Nano),
+		"level": level,
+		"msg":   msg,
+	}
+	if crawlID, ok := CrawlIDFromContext(ctx); ok {
+		entry["crawlID"] = crawlID
+	}
+	for k, v := range fields {
+		entry[k] = v
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	fmt.Fprintln(l.out, string(line))
+	l.mu.Unlock()
+
+	if l.cw != nil {
+		l.cw.Enqueue(string(line))
+	}
+}
+
+// Info logs at "info" level.
+func (l *Logger) Info(ctx context.Context, msg string, fields Fields) {
+	l.Log(ctx, "info", msg, fields)
+}
+
+// Error logs at "error" level, adding err's message under the "error" key.
+func (l *Logger) Error(ctx context.Context, msg string, err error, fields Fields) {
+	if fields == nil {
+		fields = Fields{}
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	l.Log(ctx, "error", msg, fields)
+}
@@ -0,0 +1,55 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoggerInfoIncludesCrawlID(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+	ctx := WithCrawlID(context.Background(), "crawl-1")
+
+	l.Info(ctx, "crawler fetch", Fields{"url": "https://example.com", "depth": 1})
+
+	var entry map[string]any
+	if err := json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &entry); err != nil {
+		t.Fatalf("log line is not valid JSON: %v (line: %q)", err, buf.String())
+	}
+	if entry["crawlID"] != "crawl-1" {
+		t.Errorf("crawlID = %v, want crawl-1", entry["crawlID"])
+	}
+	if entry["level"] != "info" {
+		t.Errorf("level = %v, want info", entry["level"])
+	}
+	if entry["url"] != "https://example.com" {
+		t.Errorf("url = %v, want https://example.com", entry["url"])
+	}
+}
+
+func TestLoggerErrorAddsErrorField(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf)
+
+	l.Error(context.Background(), "structured data parse error", errors.New("unexpected token"), Fields{"source": "json-ld"})
+
+	if !strings.Contains(buf.String(), `"unexpected token"`) {
+		t.Errorf("log line = %q, want it to contain the error message", buf.String())
+	}
+}
+
+func TestNilLoggerDiscardsEntries(t *testing.T) {
+	var l *Logger
+	l.Info(context.Background(), "should not panic", Fields{"k": "v"})
+	l.Error(context.Background(), "should not panic", errors.New("boom"), nil)
+}
+
+func TestCrawlIDFromContextMissing(t *testing.T) {
+	if _, ok := CrawlIDFromContext(context.Background()); ok {
+		t.Error("expected ok=false for a context with no crawlID set")
+	}
+}